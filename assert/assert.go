@@ -10,6 +10,8 @@
 //
 // This test property either passes or fails, which depends upon the evaluation of every assertion that shares its message. Different assertions in different parts of the code should have different message, but the same assertion should always have the same message even if it is moved to a different file.
 //
+// When a program is built with the antithesis_location_ids build tag, each assertion is instead identified by its message together with the file and line where it is called, so that assertions in different parts of the code which reuse a message are reported as separate test properties. Code built this way should be instrumented with the -location_ids option of the antithesis-go-instrumentor, so that the assertion catalog uses the same identifiers. Regardless of this tag, when assertions which share a message disagree about their assertion type, a diagnostic event named "antithesis_sdk_diagnostic" is emitted.
+//
 // Each function also takes a parameter called details, which is a key-value map of optional additional information provided by the user to add context for assertion failures. The information that is logged will appear in the [triage report], under the details section of the corresponding property. Normally the values passed to details are evaluated at runtime.
 //
 // [Antithesis Go SDK]: https://antithesis.com/docs/using_antithesis/sdk/go_sdk.html
@@ -24,9 +26,16 @@
 // [details]: https://antithesis.com/docs/reports/triage.html#details
 package assert
 
+import (
+	"github.com/antithesishq/antithesis-sdk-go/internal/locationkey"
+)
+
 type assertInfo struct {
+	detailsFn   func() map[string]any
+	stackTrace  string
 	Location    *locationInfo  `json:"location"`
 	Details     map[string]any `json:"details"`
+	Bucket      *bucketInfo    `json:"bucket,omitempty"`
 	AssertType  string         `json:"assert_type"`
 	DisplayType string         `json:"display_type"`
 	Message     string         `json:"message"`
@@ -99,17 +108,72 @@ func Reachable(message string, details map[string]any) {
 	assertImpl(true, message, details, locationInfo, wasHit, mustBeHit, reachabilityTest, reachableDisplay, id)
 }
 
-// AssertRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+// AlwaysLazy is equivalent to Always, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysLazy(condition bool, message string, details func() map[string]any) {
+	locationInfo := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	assertLazyImpl(condition, message, details, locationInfo, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+}
+
+// AlwaysOrUnreachableLazy is equivalent to AlwaysOrUnreachable, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysOrUnreachableLazy(condition bool, message string, details func() map[string]any) {
+	locationInfo := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	assertLazyImpl(condition, message, details, locationInfo, wasHit, optionallyHit, universalTest, alwaysOrUnreachableDisplay, id)
+}
+
+// SometimesLazy is equivalent to Sometimes, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func SometimesLazy(condition bool, message string, details func() map[string]any) {
+	locationInfo := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	assertLazyImpl(condition, message, details, locationInfo, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+}
+
+// UnreachableLazy is equivalent to Unreachable, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func UnreachableLazy(message string, details func() map[string]any) {
+	locationInfo := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	assertLazyImpl(false, message, details, locationInfo, wasHit, optionallyHit, reachabilityTest, unreachableDisplay, id)
+}
+
+// ReachableLazy is equivalent to Reachable, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func ReachableLazy(message string, details func() map[string]any) {
+	locationInfo := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	assertLazyImpl(true, message, details, locationInfo, wasHit, mustBeHit, reachabilityTest, reachableDisplay, id)
+}
+
+// AssertRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it. New frameworks should prefer EmitAssertion.
 func AssertRaw(cond bool, message string, details map[string]any,
 	classname, funcname, filename string, line int,
 	hit bool, mustHit bool,
 	assertType string, displayType string,
 	id string,
 ) {
-	assertImpl(cond, message, details,
-		&locationInfo{classname, funcname, filename, line, columnUnknown},
-		hit, mustHit,
-		assertType, displayType,
+	EmitAssertion(RawAssertion{
+		Location:    Location{Classname: classname, Funcname: funcname, Filename: filename, Line: line},
+		Details:     details,
+		Id:          id,
+		Message:     message,
+		AssertType:  assertType,
+		DisplayType: displayType,
+		Condition:   cond,
+		Hit:         hit,
+		MustHit:     mustHit,
+	})
+}
+
+// EmitAssertion is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+func EmitAssertion(assertion RawAssertion) {
+	loc := locationInfoFrom(assertion.Location)
+	id := assertion.Id
+	if id == "" {
+		id = makeKey(assertion.Message, loc)
+	}
+	assertImpl(assertion.Condition, assertion.Message, assertion.Details,
+		loc,
+		assertion.Hit, assertion.MustHit,
+		assertion.AssertType, assertion.DisplayType,
 		id)
 }
 
@@ -119,18 +183,28 @@ func assertImpl(cond bool, message string, details map[string]any,
 	assertType string, displayType string,
 	id string,
 ) {
-	trackerEntry := assertTracker.getTrackerEntry(id, loc.Filename, loc.Classname)
-
-	// Always grab the Filename and Classname captured when the trackerEntry was established
-	// This provides the consistency needed between instrumentation-time and runtime
-	if loc.Filename != trackerEntry.Filename {
-		loc.Filename = trackerEntry.Filename
-	}
-
-	if loc.Classname != trackerEntry.Classname {
-		loc.Classname = trackerEntry.Classname
+	aI := &assertInfo{
+		Hit:         hit,
+		MustHit:     mustHit,
+		AssertType:  assertType,
+		DisplayType: displayType,
+		Message:     message,
+		Condition:   cond,
+		Id:          id,
+		Location:    loc,
+		Details:     details,
 	}
+	trackAssertInfo(aI)
+}
 
+// assertLazyImpl is assertImpl for details which are only computed when
+// the tracker decides that this evaluation will be emitted
+func assertLazyImpl(cond bool, message string, detailsFn func() map[string]any,
+	loc *locationInfo,
+	hit bool, mustHit bool,
+	assertType string, displayType string,
+	id string,
+) {
 	aI := &assertInfo{
 		Hit:         hit,
 		MustHit:     mustHit,
@@ -140,12 +214,36 @@ func assertImpl(cond bool, message string, details map[string]any,
 		Condition:   cond,
 		Id:          id,
 		Location:    loc,
-		Details:     details,
+		detailsFn:   detailsFn,
+	}
+	trackAssertInfo(aI)
+}
+
+func trackAssertInfo(aI *assertInfo) {
+	loc := aI.Location
+	trackerEntry := assertTracker.getTrackerEntry(aI.Id, aI)
+
+	// Always grab the Filename and Classname captured when the trackerEntry was established
+	// This provides the consistency needed between instrumentation-time and runtime
+	if loc.Filename != trackerEntry.Filename {
+		loc.Filename = trackerEntry.Filename
+	}
+
+	if loc.Classname != trackerEntry.Classname {
+		loc.Classname = trackerEntry.Classname
+	}
+
+	if (trackerEntry.AssertType != aI.AssertType || trackerEntry.DisplayType != aI.DisplayType) && trackerEntry.conflictReported.CompareAndSwap(false, true) {
+		first := propertyKind{trackerEntry.Location, trackerEntry.AssertType, trackerEntry.DisplayType}
+		reportPropertyConflict(&first, aI)
 	}
 
 	trackerEntry.emit(aI)
 }
 
-func makeKey(message string, _ *locationInfo) string {
-	return message
+func makeKey(message string, loc *locationInfo) string {
+	if !useLocationIds || loc == nil {
+		return message
+	}
+	return locationkey.Key(message, packagePath(loc.Classname), loc.Filename, loc.Line)
 }
@@ -14,3 +14,19 @@ func AssertRaw(cond bool, message string, details map[string]any,
 	id string,
 ) {
 }
+
+func EmitAssertion(assertion RawAssertion) {}
+
+func AlwaysLazy(condition bool, message string, details func() map[string]any)              {}
+func AlwaysOrUnreachableLazy(condition bool, message string, details func() map[string]any) {}
+func SometimesLazy(condition bool, message string, details func() map[string]any)           {}
+func UnreachableLazy(message string, details func() map[string]any)                         {}
+func ReachableLazy(message string, details func() map[string]any)                           {}
+
+func Snapshot() []PropertyStatus { return nil }
+
+func SetEmitPolicy(policy EmitPolicy) EmitPolicy              { return policy }
+func SetPropertyEmitPolicy(message string, policy EmitPolicy) {}
+func ClearPropertyEmitPolicy(message string)                  {}
+
+func SetStackTraceMode(mode StackTraceMode) StackTraceMode { return mode }
@@ -1,5 +1,10 @@
 package assert
 
+import (
+	"fmt"
+	"math"
+)
+
 // Allowable numeric types of comparison parameters
 type Number interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~float32 | ~float64 | ~uint64 | ~uint | ~uintptr
@@ -28,3 +33,95 @@ func NewNamedBool(first string, second bool) *NamedBool {
 	}
 	return &p
 }
+
+// Used for multi-dimensional guidance. Maximize tells whether larger values of the dimension are more interesting than smaller ones.
+type Dimension struct {
+	Name     string  `json:"name"`
+	Value    float64 `json:"value"`
+	Maximize bool    `json:"maximize"`
+}
+
+// Convenience function to construct a Dimension whose larger values are more interesting
+func MaximizeDimension[T Number](name string, value T) Dimension {
+	return Dimension{Name: name, Value: float64(value), Maximize: true}
+}
+
+// Convenience function to construct a Dimension whose smaller values are more interesting
+func MinimizeDimension[T Number](name string, value T) Dimension {
+	return Dimension{Name: name, Value: float64(value), Maximize: false}
+}
+
+// Details keys under which SetGoroutineContext and WithPprofLabels report the goroutine that evaluated an assertion. The goroutine id replaces any value provided with its key. Pprof labels are only reported for assertions, and only from the context passed to WithPprofLabels.
+const (
+	GoroutineIdKey = "antithesis_goroutine_id"
+	PprofLabelsKey = "antithesis_pprof_labels"
+)
+
+// Location is the source location of an assertion
+type Location struct {
+	Classname string
+	Funcname  string
+	Receiver  string // The receiver type of a method, when known
+	Filename  string
+	Line      int
+	Column    int
+}
+
+// PropertyStatus describes a single property, as known to this process
+type PropertyStatus struct {
+	Location    Location // Where the property was first registered or evaluated
+	Id          string
+	Message     string
+	AssertType  string // One of "always", "sometimes" or "reachability"
+	DisplayType string // The assertion used to define the property, such as "Always" or "Unreachable"
+	PassCount   int    // Number of evaluations with a true condition
+	FailCount   int    // Number of evaluations with a false condition
+	MustHit     bool
+	Hit         bool // Whether the property has been evaluated at least once
+}
+
+// RawAssertion describes an evaluation of an assertion for EmitAssertion. It is designed to be used by third-party frameworks. Regular users of the assert package should not use it.
+type RawAssertion struct {
+	Location    Location
+	Details     map[string]any
+	Id          string // When empty, the id used by the other assertions of this package for Message at Location
+	Message     string
+	AssertType  string // One of "always", "sometimes" or "reachability"
+	DisplayType string // The assertion used to define the property, such as "Always" or "Unreachable"
+	Condition   bool
+	Hit         bool // False when the assertion is only being registered, such as by a catalog
+	MustHit     bool // Whether the assertion must be evaluated at least once
+}
+
+// RawGuidance describes guidance for EmitNumericGuidance, EmitBooleanGuidance, EmitJSONGuidance and EmitParetoGuidance. It is designed to be used by third-party frameworks. Regular users of the assert package should not use it.
+type RawGuidance struct {
+	Location Location
+	Id       string
+	Message  string
+	Behavior string // One of "maximize" or "minimize" for numeric guidance, or "all" or "none" for boolean guidance; ignored by JSON and Pareto guidance
+	Hit      bool   // False when the guidance is only being registered, such as by a catalog
+}
+
+// Tolerance is how far apart two floating point values may be while still being considered equal
+type Tolerance struct {
+	epsilon  float64
+	ulps     uint64
+	use_ulps bool
+}
+
+// Epsilon accepts values which differ by at most epsilon
+func Epsilon(epsilon float64) Tolerance {
+	return Tolerance{epsilon: math.Abs(epsilon)}
+}
+
+// ULPs accepts values which are at most n representable values apart (units in the last place)
+func ULPs(n uint64) Tolerance {
+	return Tolerance{ulps: n, use_ulps: true}
+}
+
+func (t Tolerance) String() string {
+	if t.use_ulps {
+		return fmt.Sprintf("%d ulps", t.ulps)
+	}
+	return fmt.Sprintf("epsilon %g", t.epsilon)
+}
@@ -0,0 +1,141 @@
+//go:build !no_antithesis_sdk
+
+// Package asserttest checks the properties defined with the [assert] package from within a regular go test.
+//
+// Record replaces the output of the Antithesis SDK with an in-memory recorder for the duration of a test. When the test completes, the test fails if any Always or AlwaysOrUnreachable assertion was evaluated with a false condition, if any Unreachable assertion was reached, or if any Sometimes or Reachable assertion that must be hit was never satisfied.
+//
+// Only assertions evaluated or registered while the recorder is active are considered. In particular, a Sometimes or Reachable assertion which is never called during the test is not reported, unless it was registered during the test.
+//
+// The recorder replaces process-wide state, so tests which use it should not be run in parallel with each other.
+package asserttest
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// Recorder holds the assertion and guidance records emitted while a test is running.
+type Recorder struct {
+	t          testing.TB
+	assertions []Assertion
+	guidance   []Guidance
+	mutex      sync.Mutex
+}
+
+// Record starts recording assertions and guidance for the test t. The SDK output is restored, and the recorded assertions are checked, when t completes.
+//
+// Assertions that were already emitted before Record was called (for example, by an earlier test) are forgotten, so that their first pass and first failure are emitted again within t.
+func Record(t testing.TB) *Recorder {
+	t.Helper()
+	r := &Recorder{t: t}
+
+	internal.Reset()
+	restore := internal.CaptureOutput(r.capture)
+	t.Cleanup(func() {
+		restore()
+		internal.Reset()
+		r.check()
+	})
+	return r
+}
+
+func (r *Recorder) capture(message string) {
+	var record wrappedRecord
+	if err := json.Unmarshal([]byte(message), &record); err != nil {
+		r.t.Errorf("asserttest: unable to decode SDK output %q: %v", message, err)
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if record.Assertion != nil {
+		r.assertions = append(r.assertions, *record.Assertion)
+	}
+	if record.Guidance != nil {
+		r.guidance = append(r.guidance, *record.Guidance)
+	}
+}
+
+// Assertions returns every assertion record emitted so far, including registrations (records where Hit is false).
+func (r *Recorder) Assertions() []Assertion {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	assertions := make([]Assertion, len(r.assertions))
+	copy(assertions, r.assertions)
+	return assertions
+}
+
+// Guidance returns every guidance record emitted so far.
+func (r *Recorder) Guidance() []Guidance {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	guidance := make([]Guidance, len(r.guidance))
+	copy(guidance, r.guidance)
+	return guidance
+}
+
+// Failures returns the records of Always and AlwaysOrUnreachable assertions evaluated with a false condition, and of Unreachable assertions that were reached.
+func (r *Recorder) Failures() []Assertion {
+	failures := []Assertion{}
+	for _, a := range r.Assertions() {
+		if !a.Hit || a.Condition {
+			continue
+		}
+		if a.AssertType == "always" || a.AssertType == "reachability" {
+			failures = append(failures, a)
+		}
+	}
+	return failures
+}
+
+// Unsatisfied returns one record for each Sometimes or Reachable assertion that must be hit, but which was never evaluated with a true condition.
+func (r *Recorder) Unsatisfied() []Assertion {
+	var ids []string
+	first := map[string]Assertion{}
+	satisfied := map[string]bool{}
+
+	for _, a := range r.Assertions() {
+		if a.AssertType != "sometimes" && a.AssertType != "reachability" {
+			continue
+		}
+		if !a.MustHit {
+			continue
+		}
+		if _, ok := first[a.Id]; !ok {
+			first[a.Id] = a
+			ids = append(ids, a.Id)
+		}
+		if a.Hit && a.Condition {
+			satisfied[a.Id] = true
+		}
+	}
+
+	unsatisfied := []Assertion{}
+	for _, id := range ids {
+		if !satisfied[id] {
+			unsatisfied = append(unsatisfied, first[id])
+		}
+	}
+	return unsatisfied
+}
+
+func (r *Recorder) check() {
+	r.t.Helper()
+	for _, a := range r.Failures() {
+		r.t.Errorf("%s assertion %q failed%s", a.DisplayType, a.Message, locationSuffix(a.Location))
+	}
+	for _, a := range r.Unsatisfied() {
+		r.t.Errorf("%s assertion %q was never satisfied%s", a.DisplayType, a.Message, locationSuffix(a.Location))
+	}
+}
+
+func locationSuffix(loc *Location) string {
+	if loc == nil || loc.Filename == "" {
+		return ""
+	}
+	return " at " + loc.Filename + ":" + strconv.Itoa(loc.Line)
+}
@@ -0,0 +1,89 @@
+//go:build !no_antithesis_sdk
+
+package asserttest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/assert"
+)
+
+// fakeT captures errors and cleanups so that the checks made
+// by a Recorder can be observed without failing the real test.
+type fakeT struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeT) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestRecordPassing(t *testing.T) {
+	r := Record(t)
+	assert.Always(true, "always passes", map[string]any{"n": 1})
+	for _, condition := range []bool{false, true} {
+		assert.Sometimes(condition, "sometimes passes", nil)
+	}
+	assert.Reachable("reached", nil)
+
+	assertions := r.Assertions()
+	if len(assertions) != 4 {
+		t.Fatalf("expected 4 assertion records, got %d", len(assertions))
+	}
+	if got := assertions[0].Details["n"]; got != float64(1) {
+		t.Fatalf("details did not roundtrip, got %v", got)
+	}
+}
+
+func TestRecordFailures(t *testing.T) {
+	ft := &fakeT{TB: t}
+	r := Record(ft)
+	for i := 0; i < 2; i++ {
+		assert.Always(false, "always fails", nil)
+	}
+	assert.Unreachable("never reach here", nil)
+	assert.Sometimes(false, "sometimes never true", nil)
+
+	if n := len(r.Failures()); n != 2 {
+		t.Fatalf("expected 2 failures, got %d", n)
+	}
+	if n := len(r.Unsatisfied()); n != 1 {
+		t.Fatalf("expected 1 unsatisfied assertion, got %d", n)
+	}
+
+	ft.finish()
+	if len(ft.errors) != 3 {
+		t.Fatalf("expected 3 errors to be reported, got %d: %v", len(ft.errors), ft.errors)
+	}
+}
+
+func TestRecordForgetsEarlierTests(t *testing.T) {
+	ft := &fakeT{TB: t}
+	Record(ft)
+	assert.Always(false, "fails in every test", nil)
+	ft.finish()
+
+	ft = &fakeT{TB: t}
+	r := Record(ft)
+	assert.Always(false, "fails in every test", nil)
+	ft.finish()
+
+	if n := len(r.Failures()); n != 1 {
+		t.Fatalf("expected the failure to be recorded again, got %d failures", n)
+	}
+}
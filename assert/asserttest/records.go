@@ -0,0 +1,55 @@
+package asserttest
+
+import "encoding/json"
+
+// Location is the source location reported with an assertion or guidance record.
+type Location struct {
+	Classname string `json:"class"`
+	Funcname  string `json:"function"`
+	Receiver  string `json:"receiver"`
+	Filename  string `json:"file"`
+	Line      int    `json:"begin_line"`
+	Column    int    `json:"begin_column"`
+}
+
+// Assertion is a single assertion record as emitted by the assert package.
+//
+// Details are decoded from the JSON emitted by the SDK, so numeric values
+// are float64, nested maps are map[string]any, and so on.
+type Assertion struct {
+	Location    *Location      `json:"location"`
+	Details     map[string]any `json:"details"`
+	Bucket      *Bucket        `json:"bucket,omitempty"`
+	AssertType  string         `json:"assert_type"`
+	DisplayType string         `json:"display_type"`
+	Message     string         `json:"message"`
+	Id          string         `json:"id"`
+	Hit         bool           `json:"hit"`
+	MustHit     bool           `json:"must_hit"`
+	Condition   bool           `json:"condition"`
+}
+
+// Bucket identifies an assertion emitted by SometimesEach with the property it was derived from.
+type Bucket struct {
+	Values      map[string]any `json:"values,omitempty"`
+	BaseMessage string         `json:"base_message"`
+	BaseId      string         `json:"base_id"`
+	Overflow    bool           `json:"overflow,omitempty"`
+}
+
+// Guidance is a single guidance record as emitted by the rich assertions in the assert package.
+type Guidance struct {
+	Data         json.RawMessage `json:"guidance_data,omitempty"`
+	Details      map[string]any  `json:"details,omitempty"`
+	Location     *Location       `json:"location"`
+	GuidanceType string          `json:"guidance_type"`
+	Message      string          `json:"message"`
+	Id           string          `json:"id"`
+	Maximize     bool            `json:"maximize"`
+	Hit          bool            `json:"hit"`
+}
+
+type wrappedRecord struct {
+	Assertion *Assertion `json:"antithesis_assert"`
+	Guidance  *Guidance  `json:"antithesis_guidance"`
+}
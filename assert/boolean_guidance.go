@@ -3,42 +3,45 @@
 package assert
 
 import (
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/antithesishq/antithesis-sdk-go/internal"
 )
 
-// TODO: Tracker is intended to prevent sending the same guidance
-// more than once.  In this case, we always send, so the tracker
-// is not presently used.
+// maxBooleanGuidanceVectors bounds the number of distinct combinations of
+// named bools remembered for each guidance. Once it is reached, combinations
+// which have not been seen before are no longer sent, and a diagnostic is
+// emitted the first time one is dropped.
+const maxBooleanGuidanceVectors = 1024
+
+// booleanGuidance remembers the combinations of named bools already sent,
+// so that the same combination is only sent once. Combinations are looked
+// up without a lock; count is only an approximate bound on seen.
 type booleanGuidance struct {
-	n int
+	seen    sync.Map // vector key -> struct{}
+	count   atomic.Int64
+	limited atomic.Bool
 }
 
-type booleanGuidanceTracker map[string]*booleanGuidance
-
-var (
-	boolean_guidance_tracker       booleanGuidanceTracker = make(booleanGuidanceTracker)
-	boolean_guidance_tracker_mutex sync.Mutex
-	boolean_guidance_info_mutex    sync.Mutex
-)
+// booleanGuidanceTracker maps the id of each guidance to its *booleanGuidance
+type booleanGuidanceTracker struct {
+	entries sync.Map
+}
 
-func (tracker booleanGuidanceTracker) getTrackerEntry(messageKey string) *booleanGuidance {
-	var trackerEntry *booleanGuidance
-	var ok bool
+var boolean_guidance_tracker = &booleanGuidanceTracker{}
 
+func (tracker *booleanGuidanceTracker) getTrackerEntry(messageKey string) *booleanGuidance {
 	if tracker == nil {
 		return nil
 	}
 
-	boolean_guidance_tracker_mutex.Lock()
-	defer boolean_guidance_tracker_mutex.Unlock()
-	if trackerEntry, ok = boolean_guidance_tracker[messageKey]; !ok {
-		trackerEntry = newBooleanGuidance()
-		tracker[messageKey] = trackerEntry
+	if entry, ok := tracker.entries.Load(messageKey); ok {
+		return entry.(*booleanGuidance)
 	}
-
-	return trackerEntry
+	entry, _ := tracker.entries.LoadOrStore(messageKey, newBooleanGuidance())
+	return entry.(*booleanGuidance)
 }
 
 // Create a boolean guidance tracker
@@ -47,20 +50,54 @@ func newBooleanGuidance() *booleanGuidance {
 	return &trackerInfo
 }
 
+// is_new records a combination of named bools and reports whether
+// it had not been seen before, and should therefore be sent
+func (tI *booleanGuidance) is_new(id string, named_bools []NamedBool) bool {
+	key := boolean_vector_key(named_bools)
+	if _, seen := tI.seen.Load(key); seen {
+		return false
+	}
+	if tI.count.Load() >= maxBooleanGuidanceVectors {
+		if tI.limited.CompareAndSwap(false, true) {
+			emitDiagnostic(map[string]any{
+				"kind":             "boolean_guidance_limit_exceeded",
+				"id":               id,
+				"max_combinations": maxBooleanGuidanceVectors,
+			})
+		}
+		return false
+	}
+	if _, seen := tI.seen.LoadOrStore(key, struct{}{}); seen {
+		return false
+	}
+	tI.count.Add(1)
+	return true
+}
+
+// boolean_vector_key encodes the names and values of named_bools, in order
+func boolean_vector_key(named_bools []NamedBool) string {
+	var sb strings.Builder
+	for _, named_bool := range named_bools {
+		sb.WriteString(named_bool.First)
+		if named_bool.Second {
+			sb.WriteString("\x00t\x00")
+		} else {
+			sb.WriteString("\x00f\x00")
+		}
+	}
+	return sb.String()
+}
+
 func (tI *booleanGuidance) send_value(bgI *booleanGuidanceInfo) {
 	if tI == nil {
 		return
 	}
-
-	boolean_guidance_info_mutex.Lock()
-	defer boolean_guidance_info_mutex.Unlock()
-
-	// The tracker entry should be consulted to determine
-	// if this Guidance info has already been sent, or not.
-
 	emitBooleanGuidance(bgI)
 }
 
 func emitBooleanGuidance(bgI *booleanGuidanceInfo) error {
+	if bgI.Hit {
+		bgI.Details = goroutineContext()
+	}
 	return internal.Json_data(map[string]any{"antithesis_guidance": bgI})
 }
@@ -0,0 +1,63 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBooleanGuidanceDeduplication(t *testing.T) {
+	output := captureOutput(t)
+
+	// 1000 evaluations of four distinct combinations
+	for i := 0; i < 1000; i++ {
+		named_bools := []NamedBool{{"even", i%2 == 0}, {"small", i%4 < 2}}
+		AlwaysSome(named_bools, "loop flags", nil)
+	}
+	if count := output.count(guidancePrefix); count != 4 {
+		t.Fatalf("expected each combination to be sent once, got %d records", count)
+	}
+
+	output.clear()
+	// Flag 0 is remembered, while the flags beyond the cap are dropped
+	flags := []int{}
+	for i := 0; i < maxBooleanGuidanceVectors+10; i++ {
+		flags = append(flags, i)
+	}
+	flags = append(flags, 0, maxBooleanGuidanceVectors+9)
+	for _, i := range flags {
+		SometimesAll([]NamedBool{{fmt.Sprintf("flag %d", i), true}}, "many flags", nil)
+	}
+	if count := output.count(guidancePrefix); count != maxBooleanGuidanceVectors {
+		t.Fatalf("expected combinations beyond the cap to be dropped, got %d records", count)
+	}
+	if diagnostics := output.count(diagnosticPrefix); diagnostics != 1 {
+		t.Fatalf("expected one diagnostic, got %d", diagnostics)
+	}
+}
+
+// BenchmarkBooleanGuidanceRepeated reports the guidance records emitted per
+// evaluation of an assertion which sees the same few combinations in a loop.
+// Before deduplication this was 1 record/op.
+func BenchmarkBooleanGuidanceRepeated(b *testing.B) {
+	output := captureOutput(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		named_bools := []NamedBool{{"even", i%2 == 0}, {"small", i%4 < 2}}
+		AlwaysSome(named_bools, "benchmark flags", nil)
+	}
+	b.ReportMetric(float64(output.count(guidancePrefix))/float64(b.N), "records/op")
+}
+
+func BenchmarkBooleanGuidanceRepeatedParallel(b *testing.B) {
+	output := captureOutput(b)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		named_bools := []NamedBool{{"leader", true}, {"follower", false}}
+		for pb.Next() {
+			AlwaysSome(named_bools, "benchmark flags", nil)
+		}
+	})
+	b.ReportMetric(float64(output.count(guidancePrefix))/float64(b.N), "records/op")
+}
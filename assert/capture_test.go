@@ -0,0 +1,111 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// Prefixes of the records written by the SDK
+const (
+	assertPrefix     = `{"antithesis_assert"`
+	guidancePrefix   = `{"antithesis_guidance"`
+	diagnosticPrefix = `{"antithesis_sdk_diagnostic"`
+)
+
+// capturedOutput holds the SDK output captured by captureOutput
+type capturedOutput struct {
+	tb       testing.TB
+	mutex    sync.Mutex
+	messages []string
+}
+
+// captureOutput forgets every evaluation seen so far, and captures the SDK
+// output until the end of the test or benchmark
+func captureOutput(tb testing.TB) *capturedOutput {
+	c := &capturedOutput{tb: tb}
+	resetTrackers()
+	restore := internal.CaptureOutput(func(message string) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.messages = append(c.messages, message)
+	})
+	tb.Cleanup(func() {
+		restore()
+		resetTrackers()
+	})
+	return c
+}
+
+// records returns the captured messages which start with prefix
+func (c *capturedOutput) records(prefix string) []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	records := []string{}
+	for _, message := range c.messages {
+		if strings.HasPrefix(message, prefix) {
+			records = append(records, message)
+		}
+	}
+	return records
+}
+
+func (c *capturedOutput) count(prefix string) int {
+	return len(c.records(prefix))
+}
+
+// clear forgets the messages captured so far
+func (c *capturedOutput) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.messages = nil
+}
+
+func (c *capturedOutput) assertions() []*assertInfo {
+	assertions := []*assertInfo{}
+	for _, message := range c.records(assertPrefix) {
+		var record wrappedAssertInfo
+		c.decode(message, &record)
+		assertions = append(assertions, record.A)
+	}
+	return assertions
+}
+
+func (c *capturedOutput) guidance() []*guidanceInfo {
+	guidance := []*guidanceInfo{}
+	for _, message := range c.records(guidancePrefix) {
+		var record struct {
+			Guidance *guidanceInfo `json:"antithesis_guidance"`
+		}
+		c.decode(message, &record)
+		guidance = append(guidance, record.Guidance)
+	}
+	return guidance
+}
+
+// numericData returns the operands of each numeric guidance captured
+func (c *capturedOutput) numericData() []numericOperands[int64] {
+	operands := []numericOperands[int64]{}
+	for _, message := range c.records(guidancePrefix) {
+		var record struct {
+			Guidance struct {
+				Data numericOperands[int64] `json:"guidance_data"`
+			} `json:"antithesis_guidance"`
+		}
+		c.decode(message, &record)
+		operands = append(operands, record.Guidance.Data)
+	}
+	return operands
+}
+
+func (c *capturedOutput) decode(message string, record any) {
+	c.tb.Helper()
+	if err := json.Unmarshal([]byte(message), record); err != nil {
+		c.tb.Fatalf("unexpected output %s: %v", message, err)
+	}
+}
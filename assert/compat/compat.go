@@ -0,0 +1,275 @@
+// Package compat provides assertions in the style of the testify assert package, which report Antithesis properties rather than failing a test.
+//
+// Each function is equivalent to calling [assert.Always] with the outcome of the check: it asserts that the check succeeds every time it is called, and that it is called at least once. Unlike testify, the functions take no test argument, and take a message naming the property rather than optional message arguments. When a check fails, details describing the expected and actual values are added to the details provided, which may be nil. Each function also returns whether its check succeeded.
+//
+// The antithesis-go-instrumentor catalogs calls to these functions like the assertions of the assert package.
+package compat
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Equal asserts that expected and actual are equal, as determined by reflect.DeepEqual, or by bytes.Equal if both are []byte.
+func Equal(expected, actual any, message string, details map[string]any) bool {
+	ok := objectsAreEqual(expected, actual)
+	report(ok, message, details, func() map[string]any {
+		return map[string]any{"expected": format(expected), "actual": format(actual)}
+	})
+	return ok
+}
+
+// NotEqual asserts that expected and actual are not equal, as determined by Equal.
+func NotEqual(expected, actual any, message string, details map[string]any) bool {
+	ok := !objectsAreEqual(expected, actual)
+	report(ok, message, details, func() map[string]any {
+		return map[string]any{"expected": "not " + format(expected), "actual": format(actual)}
+	})
+	return ok
+}
+
+// Nil asserts that object is nil, or is a nil chan, func, interface, map, pointer or slice.
+func Nil(object any, message string, details map[string]any) bool {
+	ok := isNil(object)
+	report(ok, message, details, func() map[string]any {
+		return map[string]any{"expected": "nil", "actual": format(object)}
+	})
+	return ok
+}
+
+// NotNil asserts that object is not nil, as determined by Nil.
+func NotNil(object any, message string, details map[string]any) bool {
+	ok := !isNil(object)
+	report(ok, message, details, func() map[string]any {
+		return map[string]any{"expected": "not nil", "actual": format(object)}
+	})
+	return ok
+}
+
+// True asserts that value is true.
+func True(value bool, message string, details map[string]any) bool {
+	report(value, message, details, func() map[string]any {
+		return map[string]any{"expected": "true", "actual": "false"}
+	})
+	return value
+}
+
+// False asserts that value is false.
+func False(value bool, message string, details map[string]any) bool {
+	report(!value, message, details, func() map[string]any {
+		return map[string]any{"expected": "false", "actual": "true"}
+	})
+	return !value
+}
+
+// Contains asserts that s contains element: a substring of a string, an element of an array or slice, or a key of a map.
+func Contains(s, element any, message string, details map[string]any) bool {
+	ok, supported := containsElement(s, element)
+	report(ok, message, details, func() map[string]any {
+		if !supported {
+			return map[string]any{"error": fmt.Sprintf("%s can not contain elements", format(s))}
+		}
+		return map[string]any{"expected": "contains " + format(element), "actual": format(s)}
+	})
+	return ok
+}
+
+// Len asserts that object, which must be an array, chan, map, slice or string, has the given length.
+func Len(object any, length int, message string, details map[string]any) bool {
+	actual, supported := getLen(object)
+	ok := supported && actual == length
+	report(ok, message, details, func() map[string]any {
+		if !supported {
+			return map[string]any{"error": fmt.Sprintf("%s has no length", format(object))}
+		}
+		return map[string]any{"expected": length, "actual": actual, "object": format(object)}
+	})
+	return ok
+}
+
+// ElementsMatch asserts that listA and listB, which must be arrays or slices, have the same elements, each the same number of times, ignoring their order.
+func ElementsMatch(listA, listB any, message string, details map[string]any) bool {
+	extraA, extraB, supported := diffLists(listA, listB)
+	ok := supported && len(extraA) == 0 && len(extraB) == 0
+	report(ok, message, details, func() map[string]any {
+		if !supported {
+			return map[string]any{"error": fmt.Sprintf("%s and %s must both be arrays or slices", format(listA), format(listB))}
+		}
+		return map[string]any{
+			"list_a":          format(listA),
+			"list_b":          format(listB),
+			"extra_in_list_a": formatAll(extraA),
+			"extra_in_list_b": formatAll(extraB),
+		}
+	})
+	return ok
+}
+
+// NoError asserts that err is nil.
+func NoError(err error, message string, details map[string]any) bool {
+	ok := err == nil
+	report(ok, message, details, func() map[string]any {
+		return map[string]any{"error": err.Error()}
+	})
+	return ok
+}
+
+// Error asserts that err is not nil.
+func Error(err error, message string, details map[string]any) bool {
+	ok := err != nil
+	report(ok, message, details, func() map[string]any {
+		return map[string]any{"expected": "an error", "actual": "nil"}
+	})
+	return ok
+}
+
+// ErrorIs asserts that errors.Is(err, target) is true.
+func ErrorIs(err, target error, message string, details map[string]any) bool {
+	ok := errors.Is(err, target)
+	report(ok, message, details, func() map[string]any {
+		return map[string]any{"expected": errorText(target), "actual": errorText(err)}
+	})
+	return ok
+}
+
+// Eventually asserts that condition returns true within waitFor. The condition is checked when Eventually is called, and then every tick until it returns true or waitFor has passed.
+func Eventually(condition func() bool, waitFor, tick time.Duration, message string, details map[string]any) bool {
+	deadline := time.Now().Add(waitFor)
+	checks := 0
+	ok := false
+	for {
+		checks++
+		if ok = condition(); ok || !time.Now().Add(tick).Before(deadline) {
+			break
+		}
+		time.Sleep(tick)
+	}
+	report(ok, message, details, func() map[string]any {
+		return map[string]any{"wait_for": waitFor.String(), "tick": tick.String(), "checks": checks}
+	})
+	return ok
+}
+
+func objectsAreEqual(expected, actual any) bool {
+	if expected == nil || actual == nil {
+		return expected == actual
+	}
+	expectedBytes, ok := expected.([]byte)
+	if !ok {
+		return reflect.DeepEqual(expected, actual)
+	}
+	actualBytes, ok := actual.([]byte)
+	if !ok {
+		return false
+	}
+	if expectedBytes == nil || actualBytes == nil {
+		return expectedBytes == nil && actualBytes == nil
+	}
+	return bytes.Equal(expectedBytes, actualBytes)
+}
+
+func isNil(object any) bool {
+	if object == nil {
+		return true
+	}
+	value := reflect.ValueOf(object)
+	switch value.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return value.IsNil()
+	}
+	return false
+}
+
+// containsElement also reports whether s is of a kind which can contain elements
+func containsElement(s, element any) (bool, bool) {
+	value := reflect.ValueOf(s)
+	switch value.Kind() {
+	case reflect.String:
+		elementValue := reflect.ValueOf(element)
+		if elementValue.Kind() != reflect.String {
+			return false, true
+		}
+		return strings.Contains(value.String(), elementValue.String()), true
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			if objectsAreEqual(key.Interface(), element) {
+				return true, true
+			}
+		}
+		return false, true
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			if objectsAreEqual(value.Index(i).Interface(), element) {
+				return true, true
+			}
+		}
+		return false, true
+	}
+	return false, false
+}
+
+func getLen(object any) (int, bool) {
+	value := reflect.ValueOf(object)
+	switch value.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len(), true
+	}
+	return 0, false
+}
+
+// diffLists returns the elements of listA which are not matched by an
+// element of listB, and those of listB not matched by an element of listA
+func diffLists(listA, listB any) ([]any, []any, bool) {
+	a, b := reflect.ValueOf(listA), reflect.ValueOf(listB)
+	for _, list := range []reflect.Value{a, b} {
+		if list.Kind() != reflect.Array && list.Kind() != reflect.Slice {
+			return nil, nil, false
+		}
+	}
+
+	var extraA, extraB []any
+	matched := make([]bool, b.Len())
+	for i := 0; i < a.Len(); i++ {
+		element := a.Index(i).Interface()
+		found := false
+		for j := 0; j < b.Len(); j++ {
+			if !matched[j] && objectsAreEqual(b.Index(j).Interface(), element) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			extraA = append(extraA, element)
+		}
+	}
+	for j := 0; j < b.Len(); j++ {
+		if !matched[j] {
+			extraB = append(extraB, b.Index(j).Interface())
+		}
+	}
+	return extraA, extraB, true
+}
+
+func format(value any) string {
+	return fmt.Sprintf("%#v", value)
+}
+
+func formatAll(values []any) []string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = format(value)
+	}
+	return formatted
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	return err.Error()
+}
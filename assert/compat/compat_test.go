@@ -0,0 +1,114 @@
+//go:build !no_antithesis_sdk
+
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+type record struct {
+	Location struct {
+		Funcname string `json:"function"`
+		Line     int    `json:"begin_line"`
+	} `json:"location"`
+	Details     map[string]any `json:"details"`
+	Message     string         `json:"message"`
+	DisplayType string         `json:"display_type"`
+	Condition   bool           `json:"condition"`
+}
+
+func capture(t *testing.T) *[]record {
+	var records []record
+	internal.Reset()
+	restore := internal.CaptureOutput(func(message string) {
+		var wrapped struct {
+			Assertion *record `json:"antithesis_assert"`
+		}
+		if err := json.Unmarshal([]byte(message), &wrapped); err != nil {
+			t.Fatalf("unexpected output %s: %v", message, err)
+		}
+		if wrapped.Assertion != nil {
+			records = append(records, *wrapped.Assertion)
+		}
+	})
+	t.Cleanup(func() {
+		restore()
+		internal.Reset()
+	})
+	return &records
+}
+
+func TestChecks(t *testing.T) {
+	wrapped := fmt.Errorf("opening: %w", fs.ErrNotExist)
+	checks := []struct {
+		name string
+		ok   bool
+		want bool
+	}{
+		{"Equal", Equal([]int{1, 2}, []int{1, 2}, "equal", nil), true},
+		{"Equal bytes", Equal([]byte{}, []byte(nil), "equal bytes", nil), false},
+		{"NotEqual", NotEqual(1, int64(1), "not equal", nil), true},
+		{"Nil", Nil((*int)(nil), "nil", nil), true},
+		{"NotNil", NotNil(map[string]int(nil), "not nil", nil), false},
+		{"True", True(true, "true", nil), true},
+		{"False", False(true, "false", nil), false},
+		{"Contains string", Contains("leader elected", "elected", "contains string", nil), true},
+		{"Contains slice", Contains([]string{"a", "b"}, "c", "contains slice", nil), false},
+		{"Contains map", Contains(map[string]int{"a": 1}, "a", "contains map", nil), true},
+		{"Contains unsupported", Contains(42, 4, "contains unsupported", nil), false},
+		{"Len", Len([]int{1, 2, 3}, 3, "len", nil), true},
+		{"Len unsupported", Len(42, 0, "len unsupported", nil), false},
+		{"ElementsMatch", ElementsMatch([]int{1, 2, 2}, []int{2, 1, 2}, "elements match", nil), true},
+		{"ElementsMatch counts", ElementsMatch([]int{1, 2, 2}, []int{1, 1, 2}, "elements match counts", nil), false},
+		{"NoError", NoError(nil, "no error", nil), true},
+		{"Error", Error(nil, "error", nil), false},
+		{"ErrorIs", ErrorIs(wrapped, fs.ErrNotExist, "error is", nil), true},
+		{"ErrorIs other", ErrorIs(wrapped, fs.ErrExist, "error is other", nil), false},
+	}
+	for _, check := range checks {
+		if check.ok != check.want {
+			t.Errorf("%s: expected %v, got %v", check.name, check.want, check.ok)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	records := capture(t)
+
+	Equal(1, 1, "values match", nil)
+	Equal(1, 2, "values match", map[string]any{"attempt": 3})
+	ElementsMatch([]string{"a", "b"}, []string{"b", "c"}, "members match", nil)
+	calls := 0
+	Eventually(func() bool { calls++; return calls == 3 }, time.Second, time.Millisecond, "converges", nil)
+
+	if len(*records) != 4 {
+		t.Fatalf("expected a pass and a failure, then two more assertions, got %+v", *records)
+	}
+	pass, fail, match, eventually := (*records)[0], (*records)[1], (*records)[2], (*records)[3]
+	if !pass.Condition || pass.DisplayType != "Always" || pass.Location.Funcname != "TestReport" || len(pass.Details) != 0 {
+		t.Fatalf("unexpected pass %+v", pass)
+	}
+	if fail.Condition || fail.Details["expected"] != "1" || fail.Details["actual"] != "2" || fail.Details["attempt"] != 3.0 || fail.Location.Line != pass.Location.Line+1 {
+		t.Fatalf("unexpected failure %+v", fail)
+	}
+	if match.Condition || fmt.Sprint(match.Details["extra_in_list_a"]) != `["a"]` || fmt.Sprint(match.Details["extra_in_list_b"]) != `["c"]` {
+		t.Fatalf("unexpected failure %+v", match)
+	}
+	if !eventually.Condition || !strings.HasPrefix(eventually.Message, "converges") {
+		t.Fatalf("unexpected assertion %+v", eventually)
+	}
+
+	if Eventually(func() bool { return false }, 5*time.Millisecond, time.Millisecond, "never converges", nil) {
+		t.Fatalf("expected the condition to time out")
+	}
+	if _, ok := (*records)[4].Details["checks"]; !ok {
+		t.Fatalf("expected the number of checks in the details, got %+v", (*records)[4])
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !no_antithesis_sdk
+
+package compat
+
+import (
+	"runtime"
+
+	"github.com/antithesishq/antithesis-sdk-go/assert"
+	"github.com/antithesishq/antithesis-sdk-go/internal/callsite"
+)
+
+// report asserts condition as an Always property at the caller of the
+// function which calls it. The details from failureDetails are only
+// computed, and added to details, when condition is false.
+func report(condition bool, message string, details map[string]any, failureDetails func() map[string]any) {
+	if !condition {
+		enhancedDetails := map[string]any{}
+		for k, v := range details {
+			enhancedDetails[k] = v
+		}
+		for k, v := range failureDetails() {
+			enhancedDetails[k] = v
+		}
+		details = enhancedDetails
+	}
+	assert.EmitAssertion(assert.RawAssertion{
+		Location:    callerLocation(),
+		Details:     details,
+		Message:     message,
+		AssertType:  "always",
+		DisplayType: "Always",
+		Condition:   condition,
+		Hit:         true,
+		MustHit:     true,
+	})
+}
+
+// callerLocation is the location of the call to the function of this
+// package which called report
+func callerLocation() assert.Location {
+	// Skip callerLocation, report and the function of this package
+	pc, filename, line, ok := runtime.Caller(3)
+	if !ok {
+		return assert.Location{Classname: "*class*", Funcname: "*function*", Filename: "*file*"}
+	}
+	loc := assert.Location{Classname: "*class*", Funcname: "*function*", Filename: filename, Line: line}
+	if this_func := runtime.FuncForPC(pc); this_func != nil {
+		loc.Classname, loc.Funcname = callsite.SplitFuncName(this_func.Name())
+	}
+	return loc
+}
@@ -0,0 +1,6 @@
+//go:build no_antithesis_sdk
+
+package compat
+
+func report(condition bool, message string, details map[string]any, failureDetails func() map[string]any) {
+}
@@ -0,0 +1,65 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+// SometimesAtLeast asserts that condition is true at least n times over the calls made with the same message. It is a Sometimes property which is satisfied by the first call at which condition has been true n times, and which is reported at that call. The number of times condition has been true so far is added to the details with the key count, and n with the key threshold.
+func SometimesAtLeast(n int, condition bool, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	countedImpl(n, condition, message, details, loc, existentialTest, sometimesDisplay, id, n, func(count int) bool { return count >= n })
+}
+
+// AlwaysAtMost asserts that condition is true at most n times over the calls made with the same message, and that it is called at least once. It is an Always property which fails at the first call at which condition has been true more than n times. The number of times condition has been true so far is added to the details with the key count, and n with the key threshold.
+func AlwaysAtMost(n int, condition bool, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	countedImpl(n, condition, message, details, loc, universalTest, alwaysDisplay, id, n+1, func(count int) bool { return count <= n })
+}
+
+func countedImpl(n int, condition bool, message string, details map[string]any,
+	loc *locationInfo,
+	assertType string, displayType string,
+	id string,
+	guidanceLimit int,
+	satisfied func(count int) bool,
+) {
+	aI := &assertInfo{
+		Hit:         wasHit,
+		MustHit:     mustBeHit,
+		AssertType:  assertType,
+		DisplayType: displayType,
+		Message:     message,
+		Id:          id,
+		Location:    loc,
+	}
+
+	// The count is kept with the property, so that it is shared by every
+	// call which reports the same property
+	trackerEntry := assertTracker.getTrackerEntry(id, aI)
+	var count int
+	if condition {
+		count = int(trackerEntry.Occurrences.Add(1))
+	} else {
+		count = int(trackerEntry.Occurrences.Load())
+	}
+
+	aI.Condition = satisfied(count)
+	aI.Details = add_counted_details(details, count, n)
+	trackAssertInfo(aI)
+
+	// Guide towards conditions being true more often, until the count
+	// reaches the one which decides the property
+	if condition && count <= guidanceLimit {
+		numericGuidanceImpl(count, n, message, id, loc, guidanceFnMaximize, wasHit)
+	}
+}
+
+func add_counted_details(details map[string]any, count, n int) map[string]any {
+	enhancedDetails := map[string]any{}
+	for k, v := range details {
+		enhancedDetails[k] = v
+	}
+	enhancedDetails["count"] = count
+	enhancedDetails["threshold"] = n
+	return enhancedDetails
+}
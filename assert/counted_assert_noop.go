@@ -0,0 +1,6 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+func SometimesAtLeast(n int, condition bool, message string, details map[string]any) {}
+func AlwaysAtMost(n int, condition bool, message string, details map[string]any)     {}
@@ -0,0 +1,32 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import "testing"
+
+func TestCountedAssertions(t *testing.T) {
+	output := captureOutput(t)
+
+	for i := 0; i < 10; i++ {
+		SometimesAtLeast(3, i%3 == 0, "elected three times", nil)
+	}
+	assertions, guidance := output.assertions(), output.count(guidancePrefix)
+	if len(assertions) != 2 || assertions[0].Condition || !assertions[1].Condition {
+		t.Fatalf("expected an unsatisfied evaluation, then the evaluation crossing the threshold, got %+v", assertions)
+	}
+	if assertions[1].Details["count"] != 3.0 || assertions[1].Details["threshold"] != 3.0 || guidance != 3 {
+		t.Fatalf("unexpected details %v with %d guidance records", assertions[1].Details, guidance)
+	}
+
+	output.clear()
+	for i := 0; i < 5; i++ {
+		AlwaysAtMost(2, true, "few retries", map[string]any{"attempt": i})
+	}
+	assertions, guidance = output.assertions(), output.count(guidancePrefix)
+	if guidance != 3 {
+		t.Fatalf("expected guidance up to the first count exceeding the threshold, got %d records", guidance)
+	}
+	if len(assertions) != 2 || !assertions[0].Condition || assertions[1].Condition || assertions[1].Details["count"] != 3.0 || assertions[1].Details["attempt"] != 2.0 {
+		t.Fatalf("expected a pass, then a failure once the threshold is exceeded, got %+v", assertions)
+	}
+}
@@ -0,0 +1,90 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"sync"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// propertyKind is the assertion type and display type first
+// seen for a message, along with the location it was seen at.
+type propertyKind struct {
+	location    locationInfo
+	assertType  string
+	displayType string
+}
+
+func (kind *propertyKind) matches(ai *assertInfo) bool {
+	return kind.assertType == ai.AssertType && kind.displayType == ai.DisplayType
+}
+
+var (
+	propertyKinds      = map[string]*propertyKind{}
+	reportedConflicts  = map[string]bool{}
+	propertyKindsMutex sync.Mutex
+)
+
+// checkPropertyKind remembers the kind of the first assertion seen with
+// each message, and reports any later assertion with the same message
+// but a different kind. It is only needed when assertions are identified
+// by location, since otherwise every use of a message shares one tracker
+// entry, which already records the first kind seen.
+func checkPropertyKind(ai *assertInfo) {
+	propertyKindsMutex.Lock()
+	kind, ok := propertyKinds[ai.Message]
+	if !ok {
+		propertyKinds[ai.Message] = &propertyKind{
+			location:    *ai.Location,
+			assertType:  ai.AssertType,
+			displayType: ai.DisplayType,
+		}
+	}
+	propertyKindsMutex.Unlock()
+
+	if ok && !kind.matches(ai) {
+		reportPropertyConflict(kind, ai)
+	}
+}
+
+// reportPropertyConflict emits a diagnostic, once per message, when an
+// assertion uses a message that was first seen with a different assertion
+// type or display type. Such assertions are aggregated into one test
+// property, which is almost never what was intended.
+func reportPropertyConflict(first *propertyKind, ai *assertInfo) {
+	propertyKindsMutex.Lock()
+	reported := reportedConflicts[ai.Message]
+	reportedConflicts[ai.Message] = true
+	propertyKindsMutex.Unlock()
+
+	if reported {
+		return
+	}
+
+	emitDiagnostic(map[string]any{
+		"kind":    "conflicting_assertion_types",
+		"message": ai.Message,
+		"first": map[string]any{
+			"assert_type":  first.assertType,
+			"display_type": first.displayType,
+			"location":     &first.location,
+		},
+		"conflicting": map[string]any{
+			"assert_type":  ai.AssertType,
+			"display_type": ai.DisplayType,
+			"location":     ai.Location,
+		},
+	})
+}
+
+func resetPropertyKinds() {
+	propertyKindsMutex.Lock()
+	defer propertyKindsMutex.Unlock()
+	propertyKinds = map[string]*propertyKind{}
+	reportedConflicts = map[string]bool{}
+}
+
+func emitDiagnostic(diagnostic map[string]any) error {
+	return internal.Json_data(map[string]any{"antithesis_sdk_diagnostic": diagnostic})
+}
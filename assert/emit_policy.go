@@ -0,0 +1,122 @@
+package assert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type emitPolicyKind int
+
+const (
+	emitFirst emitPolicyKind = iota
+	emitAll
+	emitEveryNth
+	emitRateLimited
+)
+
+// EmitPolicy decides which evaluations of a property are sent to Antithesis (or to the local output file).
+//
+// Each policy is applied separately to the passing and the failing evaluations of a property, and the first passing and first failing evaluations are always emitted. The details of each emitted evaluation are the details provided to that evaluation.
+type EmitPolicy struct {
+	kind      emitPolicyKind
+	n         int
+	perSecond int
+}
+
+// EmitFirst emits only the first passing and the first failing evaluation of a property. This is the default policy.
+func EmitFirst() EmitPolicy {
+	return EmitPolicy{kind: emitFirst}
+}
+
+// EmitAll emits every evaluation of a property.
+func EmitAll() EmitPolicy {
+	return EmitPolicy{kind: emitAll}
+}
+
+// EmitEveryNth emits the 1st, (n+1)th, (2n+1)th, ... passing evaluation of a property, and likewise for its failing evaluations. Values of n less than 1 are treated as 1.
+func EmitEveryNth(n int) EmitPolicy {
+	if n < 1 {
+		n = 1
+	}
+	return EmitPolicy{kind: emitEveryNth, n: n}
+}
+
+// EmitRateLimited emits at most perSecond passing evaluations of a property in any one-second window, and likewise for its failing evaluations. Values of perSecond less than 1 are treated as 1.
+func EmitRateLimited(perSecond int) EmitPolicy {
+	if perSecond < 1 {
+		perSecond = 1
+	}
+	return EmitPolicy{kind: emitRateLimited, perSecond: perSecond}
+}
+
+func (p EmitPolicy) String() string {
+	switch p.kind {
+	case emitAll:
+		return "all"
+	case emitEveryNth:
+		return fmt.Sprintf("every:%d", p.n)
+	case emitRateLimited:
+		return fmt.Sprintf("rate:%d", p.perSecond)
+	}
+	return "first"
+}
+
+// parseEmitPolicy accepts the same text produced by EmitPolicy.String():
+// "first", "all", "every:N" or "rate:N"
+func parseEmitPolicy(text string) (EmitPolicy, error) {
+	name, arg, has_arg := strings.Cut(strings.TrimSpace(text), ":")
+	n := 0
+	if has_arg {
+		var err error
+		if n, err = strconv.Atoi(arg); err != nil || n < 1 {
+			return EmitFirst(), fmt.Errorf("invalid emit policy %q: %q is not a positive integer", text, arg)
+		}
+	}
+
+	switch {
+	case name == "first" && !has_arg:
+		return EmitFirst(), nil
+	case name == "all" && !has_arg:
+		return EmitAll(), nil
+	case name == "every" && has_arg:
+		return EmitEveryNth(n), nil
+	case name == "rate" && has_arg:
+		return EmitRateLimited(n), nil
+	}
+	return EmitFirst(), fmt.Errorf("invalid emit policy %q: expected one of first, all, every:N or rate:N", text)
+}
+
+// parseEmitPolicies reads the emit policies configured in the environment.
+// The configuration is a list of entries separated by ";". An entry of
+// the form "message=policy" applies to the properties with that message,
+// and an entry without "=" sets the global policy. Spaces around messages
+// and policies are ignored. For example:
+//
+//	every:100;leader elected=all;disk full=rate:5
+func parseEmitPolicies(text string) (global *EmitPolicy, properties map[string]EmitPolicy, err error) {
+	properties = map[string]EmitPolicy{}
+	for _, entry := range strings.Split(text, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		message := ""
+		policy_text := entry
+		has_message := false
+		if idx := strings.LastIndex(entry, "="); idx >= 0 {
+			message, policy_text, has_message = strings.TrimSpace(entry[:idx]), entry[idx+1:], true
+		}
+
+		policy, parse_err := parseEmitPolicy(strings.TrimSpace(policy_text))
+		if parse_err != nil {
+			err = parse_err
+			continue
+		}
+		if has_message {
+			properties[message] = policy
+		} else {
+			global = &policy
+		}
+	}
+	return
+}
@@ -0,0 +1,60 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import "testing"
+
+func TestParseEmitPolicies(t *testing.T) {
+	global, properties, err := parseEmitPolicies("every:10;disk full=all; leader = elected = rate:3 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if global == nil || *global != EmitEveryNth(10) {
+		t.Fatalf("unexpected global policy: %v", global)
+	}
+	if properties["disk full"] != EmitAll() {
+		t.Fatalf("unexpected policy for 'disk full': %v", properties["disk full"])
+	}
+	if properties["leader = elected"] != EmitRateLimited(3) {
+		t.Fatalf("unexpected policies: %v", properties)
+	}
+
+	if _, _, err = parseEmitPolicies("every:0"); err == nil {
+		t.Fatalf("expected an error for every:0")
+	}
+	if _, _, err = parseEmitPolicies("sometimes"); err == nil {
+		t.Fatalf("expected an error for an unknown policy")
+	}
+}
+
+func TestEmitPolicies(t *testing.T) {
+	output := captureOutput(t)
+	defer SetEmitPolicy(SetEmitPolicy(EmitEveryNth(3)))
+	SetPropertyEmitPolicy("emit all", EmitAll())
+	defer ClearPropertyEmitPolicy("emit all")
+
+	for i := 0; i < 7; i++ {
+		Always(false, "every third", nil)
+	}
+	if count := output.count(assertPrefix); count != 3 {
+		t.Fatalf("expected 3 emissions, got %d", count)
+	}
+
+	output.clear()
+	for i := 0; i < 7; i++ {
+		Always(true, "emit all", nil)
+	}
+	if count := output.count(assertPrefix); count != 7 {
+		t.Fatalf("expected 7 emissions, got %d", count)
+	}
+
+	output.clear()
+	SetPropertyEmitPolicy("rate limited", EmitRateLimited(2))
+	defer ClearPropertyEmitPolicy("rate limited")
+	for i := 0; i < 7; i++ {
+		Always(true, "rate limited", nil)
+	}
+	if count := output.count(assertPrefix); count != 2 {
+		t.Fatalf("expected 2 emissions, got %d", count)
+	}
+}
@@ -0,0 +1,264 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// AlwaysEqual asserts that left == right every time this function is called, and that it is called at least once. Information about left and right will automatically be added to the details parameter, with keys left and right. When they differ, a description of the difference is added with key diff. When they can not be compared, such as two slices held in interface values, the evaluation fails and the reason is added with key error. If left and right are numbers, Antithesis will try to drive them apart.
+func AlwaysEqual[T comparable](left, right T, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition, err := equal_values(left, right)
+	assertLazyImpl(condition, message, lazy_equality_details(details, left, right, condition, err), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	equalityGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize)
+}
+
+// SometimesEqual asserts that left == right at least one time that this function is called. Information about left and right will automatically be added to the details parameter, as described for AlwaysEqual. If left and right are numbers, Antithesis will try to bring them together.
+func SometimesEqual[T comparable](left, right T, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition, err := equal_values(left, right)
+	assertLazyImpl(condition, message, lazy_equality_details(details, left, right, condition, err), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	equalityGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize)
+}
+
+// AlwaysNotEqual asserts that left != right every time this function is called, and that it is called at least once. Information about left and right will automatically be added to the details parameter, with keys left and right. When they can not be compared, the evaluation fails as described for AlwaysEqual. If left and right are numbers, Antithesis will try to bring them together.
+func AlwaysNotEqual[T comparable](left, right T, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	equal, err := equal_values(left, right)
+	condition := !equal && err == nil
+	assertLazyImpl(condition, message, lazy_equality_details(details, left, right, true, err), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	equalityGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize)
+}
+
+// AlwaysApproxEqual asserts that left and right are equal within tolerance every time this function is called, and that it is called at least once. NaN is never approximately equal to anything. Information about left, right and tolerance will automatically be added to the details parameter, with keys left, right and tolerance. Antithesis will try to drive left and right apart.
+func AlwaysApproxEqual[T ~float32 | ~float64](left, right T, tolerance Tolerance, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := approx_equal(left, right, tolerance)
+	detailsFn := func() map[string]any {
+		enhancedDetails := add_numeric_details(details, left, right)
+		enhancedDetails["tolerance"] = tolerance.String()
+		return enhancedDetails
+	}
+	assertLazyImpl(condition, message, detailsFn, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	gap := math.Abs(float64(left) - float64(right))
+	numericGuidanceImpl(gap, 0, message, id, loc, guidanceFnMaximize, wasHit)
+}
+
+func approx_equal[T ~float32 | ~float64](left, right T, tolerance Tolerance) bool {
+	l, r := float64(left), float64(right)
+	if math.IsNaN(l) || math.IsNaN(r) {
+		return false
+	}
+	if l == r {
+		return true
+	}
+	if !tolerance.use_ulps {
+		return math.Abs(l-r) <= tolerance.epsilon
+	}
+	// Count the representable values between left and right
+	// at the precision of T, rather than that of float64
+	if reflect.TypeOf(left).Kind() == reflect.Float32 {
+		return ulp_distance(uint64(ordered_float32_bits(float32(l))), uint64(ordered_float32_bits(float32(r)))) <= tolerance.ulps
+	}
+	return ulp_distance(ordered_float64_bits(l), ordered_float64_bits(r)) <= tolerance.ulps
+}
+
+// equal_values evaluates left == right. When T is an interface type, or
+// holds one, == panics if it compares values of the same dynamic type
+// which is not comparable, such as slices or maps. Such comparisons
+// return the error instead, so that they fail rather than panic.
+func equal_values[T comparable](left, right T) (equal bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			equal, err = false, fmt.Errorf("%v", r)
+		}
+	}()
+	return left == right, nil
+}
+
+// ordered_float64_bits maps a float64 onto a uint64 so that adjacent
+// representable values map onto adjacent integers
+func ordered_float64_bits(f float64) uint64 {
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		return ^bits
+	}
+	return bits | (1 << 63)
+}
+
+func ordered_float32_bits(f float32) uint32 {
+	bits := math.Float32bits(f)
+	if bits&(1<<31) != 0 {
+		return ^bits
+	}
+	return bits | (1 << 31)
+}
+
+func ulp_distance(left, right uint64) uint64 {
+	if left > right {
+		return left - right
+	}
+	return right - left
+}
+
+// equalityGuidanceImpl provides guidance on abs(left - right) when
+// left and right are numbers, and does nothing for other types. When T
+// is an interface type, it does nothing unless left and right hold
+// numbers of the same type.
+func equalityGuidanceImpl[T comparable](left, right T, message, id string, loc *locationInfo, guidanceFn guidanceFnType) {
+	l := reflect.ValueOf(left)
+	r := reflect.ValueOf(right)
+	if !same_type(l, r) {
+		return
+	}
+	switch l.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		a, b := l.Int(), r.Int()
+		var gap uint64
+		if a >= b {
+			gap = uint64(a) - uint64(b)
+		} else {
+			gap = uint64(b) - uint64(a)
+		}
+		numericGuidanceImpl(gap, 0, message, id, loc, guidanceFn, wasHit)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		a, b := l.Uint(), r.Uint()
+		var gap uint64
+		if a >= b {
+			gap = a - b
+		} else {
+			gap = b - a
+		}
+		numericGuidanceImpl(gap, 0, message, id, loc, guidanceFn, wasHit)
+	case reflect.Float32, reflect.Float64:
+		gap := math.Abs(l.Float() - r.Float())
+		numericGuidanceImpl(gap, 0, message, id, loc, guidanceFn, wasHit)
+	}
+}
+
+// same_type reports whether left and right both hold values of the same
+// type, which is not the case for nil or for different types held in an
+// interface
+func same_type(left, right reflect.Value) bool {
+	return left.IsValid() && right.IsValid() && left.Type() == right.Type()
+}
+
+func lazy_equality_details[T comparable](details map[string]any, left, right T, equal bool, err error) func() map[string]any {
+	return func() map[string]any {
+		// ----------------------------------------------------
+		// Can not use maps.Clone() until go 1.21.0 or above
+		// enhancedDetails := maps.Clone(details)
+		// ----------------------------------------------------
+		enhancedDetails := map[string]any{}
+		for k, v := range details {
+			enhancedDetails[k] = v
+		}
+		enhancedDetails["left"] = detail_value(left)
+		enhancedDetails["right"] = detail_value(right)
+		if err != nil {
+			enhancedDetails["error"] = err.Error()
+		} else if !equal {
+			if diff := describe_difference(reflect.ValueOf(left), reflect.ValueOf(right)); diff != nil {
+				enhancedDetails["diff"] = diff
+			}
+		}
+		return enhancedDetails
+	}
+}
+
+// detail_value replaces values that can not be represented in JSON, such
+// as channels or structs with a func field, with their text
+func detail_value(v any) any {
+	if duration, ok := v.(time.Duration); ok {
+		return duration.String()
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return v
+}
+
+// describe_difference explains how two unequal strings or structs differ.
+// It returns nil for other types, where left and right speak for themselves,
+// and when left and right do not hold values of the same type.
+func describe_difference(left, right reflect.Value) any {
+	if !same_type(left, right) {
+		return nil
+	}
+	switch left.Kind() {
+	case reflect.String:
+		return string_difference(left.String(), right.String())
+	case reflect.Struct:
+		return struct_difference(left, right)
+	}
+	return nil
+}
+
+// diffContext is the number of bytes shown around the first difference in two strings
+const diffContext = 20
+
+func string_difference(left, right string) string {
+	idx := 0
+	for idx < len(left) && idx < len(right) && left[idx] == right[idx] {
+		idx++
+	}
+	snippet := func(s string) string {
+		start := idx - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := idx + diffContext
+		if end > len(s) {
+			end = len(s)
+		}
+		text := fmt.Sprintf("%q", s[start:end])
+		if start > 0 {
+			text = "..." + text
+		}
+		if end < len(s) {
+			text = text + "..."
+		}
+		return text
+	}
+	return fmt.Sprintf("strings differ at byte %d (lengths %d and %d): left %s, right %s", idx, len(left), len(right), snippet(left), snippet(right))
+}
+
+func struct_difference(left, right reflect.Value) []string {
+	differences := []string{}
+	if !same_type(left, right) {
+		return differences
+	}
+	struct_type := left.Type()
+	for i := 0; i < left.NumField(); i++ {
+		l, r := left.Field(i), right.Field(i)
+		name := struct_type.Field(i).Name
+		if !l.Comparable() || !r.Comparable() {
+			differences = append(differences, fmt.Sprintf("%s: %#v and %#v can not be compared", name, l, r))
+			continue
+		}
+		if l.Equal(r) {
+			continue
+		}
+		if l.Kind() == reflect.Struct && same_type(l, r) {
+			for _, nested := range struct_difference(l, r) {
+				differences = append(differences, name+"."+nested)
+			}
+			continue
+		}
+		differences = append(differences, fmt.Sprintf("%s: %#v != %#v", name, l, r))
+	}
+	return differences
+}
@@ -0,0 +1,9 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+func AlwaysEqual[T comparable](left, right T, message string, details map[string]any)    {}
+func SometimesEqual[T comparable](left, right T, message string, details map[string]any) {}
+func AlwaysNotEqual[T comparable](left, right T, message string, details map[string]any) {}
+func AlwaysApproxEqual[T ~float32 | ~float64](left, right T, tolerance Tolerance, message string, details map[string]any) {
+}
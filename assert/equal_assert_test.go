@@ -0,0 +1,133 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestApproxEqual(t *testing.T) {
+	next64 := math.Nextafter(1.0, 2.0)
+	if !approx_equal(1.0, next64, ULPs(1)) || approx_equal(1.0, math.Nextafter(next64, 2.0), ULPs(1)) {
+		t.Fatalf("unexpected float64 ulp comparison")
+	}
+
+	next32 := math.Nextafter32(1.0, 2.0)
+	if !approx_equal(float32(1.0), next32, ULPs(1)) {
+		t.Fatalf("float32 values should be compared at float32 precision")
+	}
+
+	if !approx_equal(-0.0, 0.0, ULPs(0)) || !approx_equal(math.Inf(1), math.Inf(1), Epsilon(0)) {
+		t.Fatalf("equal values should always be approximately equal")
+	}
+	if approx_equal(math.NaN(), math.NaN(), Epsilon(math.Inf(1))) {
+		t.Fatalf("NaN should never be approximately equal")
+	}
+	if !approx_equal(1.0, 1.25, Epsilon(0.5)) || approx_equal(1.0, 2.0, Epsilon(-0.5)) {
+		t.Fatalf("unexpected epsilon comparison")
+	}
+}
+
+func TestDescribeDifference(t *testing.T) {
+	diff := describe_difference(reflect.ValueOf("hello world"), reflect.ValueOf("hello there"))
+	if text, ok := diff.(string); !ok || !strings.Contains(text, "byte 6") {
+		t.Fatalf("unexpected string difference: %v", diff)
+	}
+
+	type inner struct{ port int }
+	type config struct {
+		Name    string
+		Address inner
+		Debug   bool
+	}
+	diff = describe_difference(
+		reflect.ValueOf(config{"a", inner{80}, true}),
+		reflect.ValueOf(config{"a", inner{8080}, false}))
+	want := []string{"Address.port: 80 != 8080", "Debug: true != false"}
+	if !reflect.DeepEqual(diff, want) {
+		t.Fatalf("got %v, want %v", diff, want)
+	}
+}
+
+func TestEqualInterfaceTypes(t *testing.T) {
+	output := captureOutput(t)
+	defer SetEmitPolicy(SetEmitPolicy(EmitAll()))
+
+	var err error = syscall.Errno(2)
+	AlwaysEqual(err, nil, "error is nil", nil)
+
+	type endpoint struct {
+		Name   string
+		Notify chan int
+	}
+	values := [][2]any{
+		{1, "a"},
+		{1, uint(1)},
+		{nil, 2},
+		{endpoint{Name: "a"}, endpoint{Name: "b", Notify: make(chan int)}},
+		{3, 5},
+	}
+	for _, v := range values {
+		AlwaysEqual(v[0], v[1], "values are equal", nil)
+	}
+
+	assertions := output.assertions()
+	if len(assertions) != len(values)+1 {
+		t.Fatalf("expected every evaluation to be reported, got %d assertions", len(assertions))
+	}
+	for _, assertion := range assertions {
+		if assertion.Condition {
+			t.Fatalf("expected every evaluation to fail, got %+v", assertion)
+		}
+	}
+	if details := assertions[0].Details; details["left"] != 2.0 || details["right"] != nil {
+		t.Fatalf("unexpected details for an error: %v", details)
+	}
+	for _, assertion := range assertions[1:4] {
+		if _, ok := assertion.Details["diff"]; ok {
+			t.Fatalf("expected no diff for values of different types, got %v", assertion.Details)
+		}
+	}
+	details := assertions[4].Details
+	if _, ok := details["left"].(string); !ok {
+		t.Fatalf("expected a struct which can not be encoded to be reported as text, got %v", details)
+	}
+	if diff, _ := details["diff"].([]any); len(diff) != 2 {
+		t.Fatalf("expected both fields to differ, got %v", details)
+	}
+
+	if operands := output.numericData(); len(operands) != 1 || operands[0].Left != 2 {
+		t.Fatalf("expected guidance only for numbers of the same type, got %v", operands)
+	}
+}
+
+func TestEqualUncomparableValues(t *testing.T) {
+	output := captureOutput(t)
+	defer SetEmitPolicy(SetEmitPolicy(EmitAll()))
+
+	type labels struct {
+		Name  string
+		Value any
+	}
+	AlwaysEqual[any]([]int{1}, []int{1}, "slices are equal", nil)
+	AlwaysNotEqual[any](map[string]int{}, map[string]int{}, "maps differ", nil)
+	AlwaysEqual(labels{"a", []int{1}}, labels{"b", []int{1}}, "labels are equal", nil)
+
+	assertions := output.assertions()
+	if len(assertions) != 3 {
+		t.Fatalf("expected every evaluation to be reported, got %d assertions", len(assertions))
+	}
+	for _, assertion := range assertions[:2] {
+		if assertion.Condition || !strings.Contains(fmt.Sprint(assertion.Details["error"]), "uncomparable") {
+			t.Fatalf("expected a failed evaluation with the reason, got %+v", assertion)
+		}
+	}
+	if diff, _ := assertions[2].Details["diff"].([]any); assertions[2].Condition || len(diff) != 2 {
+		t.Fatalf("expected both fields to be described, got %+v", assertions[2])
+	}
+}
@@ -0,0 +1,99 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxErrorChain limits how many wrapped errors are reported in details
+const maxErrorChain = 32
+
+// AlwaysNoError asserts that err is nil every time this function is called, and that it is called at least once. It is equivalent to Always(err == nil, message, details), except that when err is not nil, information about err will automatically be added to the details parameter: its message with key error, its concrete type with key error_type, and the errors it wraps with key error_chain.
+func AlwaysNoError(err error, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := err == nil
+	assertLazyImpl(condition, message, lazy_error_details(details, err, nil), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+}
+
+// SometimesError asserts that err is not nil at least one time that this function is called. It is equivalent to Sometimes(err != nil, message, details), except that information about err will automatically be added to the details parameter, as described for AlwaysNoError. Use it to check that an error path is exercised.
+func SometimesError(err error, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := err != nil
+	assertLazyImpl(condition, message, lazy_error_details(details, err, nil), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+}
+
+// SometimesErrorIs asserts that errors.Is(err, target) is true at least one time that this function is called. Information about err will automatically be added to the details parameter, as described for AlwaysNoError, and information about target will be added with keys target and target_type.
+func SometimesErrorIs(err error, target error, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := errors.Is(err, target)
+	assertLazyImpl(condition, message, lazy_error_details(details, err, target), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+}
+
+func lazy_error_details(details map[string]any, err error, target error) func() map[string]any {
+	return func() map[string]any {
+		return add_error_details(details, err, target)
+	}
+}
+
+func add_error_details(details map[string]any, err error, target error) map[string]any {
+	if err == nil && target == nil {
+		return details
+	}
+	// ----------------------------------------------------
+	// Can not use maps.Clone() until go 1.21.0 or above
+	// enhancedDetails := maps.Clone(details)
+	// ----------------------------------------------------
+	enhancedDetails := map[string]any{}
+	for k, v := range details {
+		enhancedDetails[k] = v
+	}
+	if err != nil {
+		enhancedDetails["error"] = err.Error()
+		enhancedDetails["error_type"] = fmt.Sprintf("%T", err)
+		if chain := error_chain(err); len(chain) > 0 {
+			enhancedDetails["error_chain"] = chain
+		}
+	}
+	if target != nil {
+		enhancedDetails["target"] = target.Error()
+		enhancedDetails["target_type"] = fmt.Sprintf("%T", target)
+	}
+	return enhancedDetails
+}
+
+// error_chain lists the errors wrapped by err, depth first, in the
+// same order that errors.Is() would visit them.
+func error_chain(err error) []map[string]string {
+	chain := []map[string]string{}
+	pending := unwrap_error(err)
+	for len(pending) > 0 && len(chain) < maxErrorChain {
+		next := pending[0]
+		pending = pending[1:]
+		if next == nil {
+			continue
+		}
+		chain = append(chain, map[string]string{
+			"error": next.Error(),
+			"type":  fmt.Sprintf("%T", next),
+		})
+		pending = append(unwrap_error(next), pending...)
+	}
+	return chain
+}
+
+func unwrap_error(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if wrapped := e.Unwrap(); wrapped != nil {
+			return []error{wrapped}
+		}
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	}
+	return nil
+}
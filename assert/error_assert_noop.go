@@ -0,0 +1,7 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+func AlwaysNoError(err error, message string, details map[string]any)                  {}
+func SometimesError(err error, message string, details map[string]any)                 {}
+func SometimesErrorIs(err error, target error, message string, details map[string]any) {}
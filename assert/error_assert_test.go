@@ -0,0 +1,47 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestErrorDetails(t *testing.T) {
+	wrapped := fmt.Errorf("reading header: %w", errors.Join(io.ErrUnexpectedEOF, io.ErrClosedPipe))
+	details := add_error_details(map[string]any{"attempt": 2}, wrapped, io.ErrClosedPipe)
+
+	if details["attempt"] != 2 {
+		t.Fatalf("user details were not preserved: %v", details)
+	}
+	if details["error"] != wrapped.Error() || details["error_type"] != "*fmt.wrapError" {
+		t.Fatalf("unexpected error details: %v", details)
+	}
+	if details["target"] != io.ErrClosedPipe.Error() {
+		t.Fatalf("unexpected target details: %v", details)
+	}
+
+	chain := details["error_chain"].([]map[string]string)
+	want := []string{
+		errors.Join(io.ErrUnexpectedEOF, io.ErrClosedPipe).Error(),
+		io.ErrUnexpectedEOF.Error(),
+		io.ErrClosedPipe.Error(),
+	}
+	if len(chain) != len(want) {
+		t.Fatalf("unexpected error chain: %v", chain)
+	}
+	for i := range want {
+		if chain[i]["error"] != want[i] {
+			t.Fatalf("unexpected error chain entry %d: %v", i, chain[i])
+		}
+	}
+}
+
+func TestNoErrorDetails(t *testing.T) {
+	details := map[string]any{"attempt": 2}
+	if got := add_error_details(details, nil, nil); len(got) != 1 {
+		t.Fatalf("expected details to be unchanged, got %v", got)
+	}
+}
@@ -0,0 +1,123 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+)
+
+// maxExploreStates bounds the number of distinct states remembered for each
+// Explore guidance. Once it is reached, states which have not been seen
+// before are no longer sent, and a diagnostic is emitted the first time
+// one is dropped.
+const maxExploreStates = 4096
+
+// exploreGuidance remembers the states already sent by a 64-bit hash of
+// their encoding, so that its memory stays bounded however large the
+// states are. States are looked up without a lock; count is only an
+// approximate bound on seen.
+type exploreGuidance struct {
+	seen    sync.Map // uint64 -> struct{}
+	count   atomic.Int64
+	limited atomic.Bool
+}
+
+// exploreGuidanceTracker maps the id of each guidance to its *exploreGuidance
+type exploreGuidanceTracker struct {
+	entries sync.Map
+}
+
+var explore_guidance_tracker = &exploreGuidanceTracker{}
+
+// exploreStateSeed is the seed of the hashes of every state. States are
+// only compared within a process, so the seed need not be stable.
+var exploreStateSeed = maphash.MakeSeed()
+
+func (tracker *exploreGuidanceTracker) getTrackerEntry(messageKey string) *exploreGuidance {
+	if entry, ok := tracker.entries.Load(messageKey); ok {
+		return entry.(*exploreGuidance)
+	}
+	entry, _ := tracker.entries.LoadOrStore(messageKey, &exploreGuidance{})
+	return entry.(*exploreGuidance)
+}
+
+// is_new records the state and reports whether it had not been seen
+// before, and should therefore be sent
+func (tI *exploreGuidance) is_new(id string, state []byte) bool {
+	key := maphash.Bytes(exploreStateSeed, state)
+	if _, seen := tI.seen.Load(key); seen {
+		return false
+	}
+	if tI.count.Load() >= maxExploreStates {
+		if tI.limited.CompareAndSwap(false, true) {
+			emitDiagnostic(map[string]any{
+				"kind":       "explore_guidance_limit_exceeded",
+				"id":         id,
+				"max_states": maxExploreStates,
+			})
+		}
+		return false
+	}
+	if _, seen := tI.seen.LoadOrStore(key, struct{}{}); seen {
+		return false
+	}
+	tI.count.Add(1)
+	return true
+}
+
+// Explore reports state, a structured snapshot of what your program or workload is doing (such as the phase of a protocol, or the members of a cluster), so that Antithesis can favor explorations which reach states it has not seen before. The state must be encodable with encoding/json; states which are not are ignored.
+//
+// Each distinct state is only reported once for each message. States are compared by a 64-bit hash of their JSON encoding rather than by the encoding itself, so that their size does not matter; in the unlikely event that two different states have the same hash, only the first is sent. At most 4096 distinct states are sent for each message; further new states are dropped, and a diagnostic event named "antithesis_sdk_diagnostic" is emitted.
+func Explore(message string, state any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	exploreGuidanceImpl(state, message, id, loc, wasHit)
+}
+
+func exploreGuidanceImpl(state any, message, id string, loc *locationInfo, hit bool) {
+	guidance_location_tracker.normalize(id, loc)
+	gI := &guidanceInfo{
+		GuidanceType: get_guidance_type_string(guidanceFnExplore),
+		Message:      message,
+		Id:           id,
+		Location:     loc,
+		Maximize:     uses_maximize(guidanceFnExplore),
+		Hit:          hit,
+	}
+
+	// Registrations carry no state
+	if !hit {
+		emitGuidance(gI)
+		return
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if !explore_guidance_tracker.getTrackerEntry(id).is_new(id, data) {
+		return
+	}
+	gI.Data = json.RawMessage(data)
+	emitGuidance(gI)
+}
+
+// JSONGuidanceRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it. New frameworks should prefer EmitJSONGuidance.
+func JSONGuidanceRaw(
+	state any,
+	message, id string,
+	classname, funcname, filename string,
+	line int,
+	hit bool,
+) {
+	EmitJSONGuidance(rawGuidance(message, id, classname, funcname, filename, line, "", hit), state)
+}
+
+// EmitJSONGuidance is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+func EmitJSONGuidance(guidance RawGuidance, state any) {
+	loc := locationInfoFrom(guidance.Location)
+	exploreGuidanceImpl(state, guidance.Message, guidance.Id, loc, guidance.Hit)
+}
@@ -0,0 +1,16 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+func Explore(message string, state any) {}
+
+func JSONGuidanceRaw(
+	state any,
+	message, id string,
+	classname, funcname, filename string,
+	line int,
+	hit bool,
+) {
+}
+
+func EmitJSONGuidance(guidance RawGuidance, state any) {}
@@ -0,0 +1,55 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplore(t *testing.T) {
+	output := captureOutput(t)
+
+	type membership struct {
+		Phase   string   `json:"phase"`
+		Members []string `json:"members"`
+	}
+	states := []any{
+		membership{"joining", []string{"a"}},
+		membership{"joining", []string{"a"}},
+		membership{"stable", []string{"a", "b"}},
+		func() {},
+	}
+	for _, state := range states {
+		Explore("cluster state", state)
+	}
+
+	sent := output.records(guidancePrefix)
+	if len(sent) != 2 {
+		t.Fatalf("expected each distinct state to be sent once, got %q", sent)
+	}
+	if !strings.Contains(sent[1], `"guidance_data":{"phase":"stable","members":["a","b"]}`) || !strings.Contains(sent[1], `"guidance_type":"json"`) {
+		t.Fatalf("unexpected guidance: %s", sent[1])
+	}
+}
+
+func TestExploreLimit(t *testing.T) {
+	output := captureOutput(t)
+
+	// States are reported from one call site, which is one guidance even
+	// when built with location ids
+	states := []int{}
+	for i := 0; i < maxExploreStates+10; i++ {
+		states = append(states, i)
+	}
+	states = append(states, maxExploreStates+5, 0)
+	for _, state := range states {
+		Explore("request count", state)
+	}
+	if sent := output.count(guidancePrefix); sent != maxExploreStates {
+		t.Fatalf("expected %d states to be sent, got %d", maxExploreStates, sent)
+	}
+	if diagnostics := output.count(diagnosticPrefix); diagnostics != 1 {
+		t.Fatalf("expected one diagnostic once the limit is reached, got %d", diagnostics)
+	}
+}
@@ -0,0 +1,104 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+var includeGoroutineContext atomic.Bool
+
+func init() {
+	if text, ok := os.LookupEnv(internal.GoroutineContextEnvVar); ok {
+		enabled, err := strconv.ParseBool(text)
+		if err != nil {
+			log.Printf("%s %s: %v", internal.ErrorLogLinePrefix, internal.GoroutineContextEnvVar, err)
+		}
+		includeGoroutineContext.Store(enabled)
+	}
+}
+
+// SetGoroutineContext sets whether emitted assertions and guidance include the id of the goroutine that evaluated them, and returns the previous setting. The id is reported with the key GoroutineIdKey; for guidance, it is reported in a details field of its own. While enabled, WithPprofLabels also adds the pprof labels of a context to the details of an assertion.
+//
+// The pprof labels of the goroutine that evaluates an assertion are not attached automatically, since the runtime does not expose them, and they are never attached to guidance. Labels are only reported for assertions whose details are passed through WithPprofLabels.
+//
+// This is disabled by default, unless the environment variable ANTITHESIS_SDK_GOROUTINE_CONTEXT is set to true. When disabled it costs nothing; when enabled it adds some cost to each evaluation that is emitted, and WithPprofLabels adds some cost to every call, whether or not the evaluation it is passed to is emitted.
+func SetGoroutineContext(enabled bool) bool {
+	return includeGoroutineContext.Swap(enabled)
+}
+
+// WithPprofLabels returns details together with the pprof labels of ctx, such as the context passed by pprof.Do to the function it runs, reported with the key PprofLabelsKey. It is the only way labels are reported, and only for assertions: the labels of the goroutine evaluating an assertion are not collected automatically, since they can only be read from a context.
+//
+// The details provided are not modified. While SetGoroutineContext is enabled, each call reads the labels of ctx and copies details, even when the evaluation is not emitted, so it is best kept out of hot paths. Unless SetGoroutineContext is enabled, or when ctx has no labels, details is returned as is.
+//
+//	pprof.Do(ctx, pprof.Labels("shard", "3"), func(ctx context.Context) {
+//		assert.Always(ok, "shard is consistent", assert.WithPprofLabels(ctx, details))
+//	})
+func WithPprofLabels(ctx context.Context, details map[string]any) map[string]any {
+	if !includeGoroutineContext.Load() || ctx == nil {
+		return details
+	}
+	labels := map[string]string{}
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		labels[key] = value
+		return true
+	})
+	if len(labels) == 0 {
+		return details
+	}
+	labeled := make(map[string]any, len(details)+1)
+	for k, v := range details {
+		labeled[k] = v
+	}
+	labeled[PprofLabelsKey] = labels
+	return labeled
+}
+
+// goroutineContext returns the details describing the current goroutine,
+// or nil when they are not included
+func goroutineContext() map[string]any {
+	if !includeGoroutineContext.Load() {
+		return nil
+	}
+	details := map[string]any{}
+	if id, ok := currentGoroutineId(); ok {
+		details[GoroutineIdKey] = id
+	}
+	return details
+}
+
+// currentGoroutineId reads the id from the first line of the
+// goroutine's stack trace, which is of the form "goroutine 7 [running]:"
+func currentGoroutineId() (int64, bool) {
+	var buf [64]byte
+	fields := bytes.Fields(buf[:runtime.Stack(buf[:], false)])
+	if len(fields) < 2 {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(string(fields[1]), 10, 64)
+	return id, err == nil
+}
+
+// addGoroutineContext returns details together with the details
+// describing the current goroutine, if they are included
+func addGoroutineContext(details map[string]any) map[string]any {
+	goroutine := goroutineContext()
+	if goroutine == nil {
+		return details
+	}
+	for k, v := range details {
+		if _, reserved := goroutine[k]; !reserved {
+			goroutine[k] = v
+		}
+	}
+	return goroutine
+}
@@ -0,0 +1,9 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+import "context"
+
+func SetGoroutineContext(enabled bool) bool { return enabled }
+
+func WithPprofLabels(ctx context.Context, details map[string]any) map[string]any { return details }
@@ -0,0 +1,49 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+)
+
+func TestGoroutineContext(t *testing.T) {
+	output := captureOutput(t)
+	labels := pprof.Labels("shard", "3", "kind", "read")
+
+	pprof.Do(context.Background(), labels, func(ctx context.Context) {
+		Always(true, "without goroutine context", WithPprofLabels(ctx, map[string]any{"n": 1}))
+	})
+	if assertions := output.assertions(); len(assertions) != 1 || len(assertions[0].Details) != 1 {
+		t.Fatalf("expected only the details provided, got %+v", assertions)
+	}
+
+	output.clear()
+	defer SetGoroutineContext(SetGoroutineContext(true))
+	details := map[string]any{"n": 1}
+	pprof.Do(context.Background(), labels, func(ctx context.Context) {
+		Always(true, "with goroutine context", WithPprofLabels(ctx, details))
+		AlwaysLessThan(1, 2, "guidance with goroutine context", nil)
+	})
+	assertions, guidance := output.assertions(), output.guidance()
+	if len(assertions) != 2 || len(guidance) != 1 {
+		t.Fatalf("expected two assertions and a guidance, got %+v and %+v", assertions, guidance)
+	}
+	for _, d := range []map[string]any{assertions[0].Details, assertions[1].Details, guidance[0].Details} {
+		if id, _ := d[GoroutineIdKey].(float64); id <= 0 {
+			t.Fatalf("unexpected goroutine id in %v", d)
+		}
+	}
+	reported, _ := assertions[0].Details[PprofLabelsKey].(map[string]any)
+	if reported["shard"] != "3" || reported["kind"] != "read" || assertions[0].Details["n"] != float64(1) {
+		t.Fatalf("expected the labels and the details provided, got %v", assertions[0].Details)
+	}
+	if len(details) != 1 {
+		t.Fatalf("the details provided should not be modified, got %v", details)
+	}
+
+	if labeled := WithPprofLabels(context.Background(), details); len(labeled) != 1 {
+		t.Fatalf("expected no labels outside pprof.Do, got %v", labeled)
+	}
+}
@@ -0,0 +1,117 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+type invariant struct {
+	check   func() (bool, map[string]any)
+	loc     *locationInfo
+	message string
+	id      string
+}
+
+var (
+	invariants          []*invariant
+	invariantStop       chan struct{}
+	invariantsMutex     sync.Mutex
+	invariantCheckMutex sync.Mutex // one evaluation of the invariants at a time
+
+	// invariantCheckGoroutine is the id of the goroutine evaluating the
+	// invariants, or 0. Goroutine ids start at 1.
+	invariantCheckGoroutine atomic.Int64
+)
+
+func init() {
+	internal.RegisterLifecycleHook(CheckInvariants)
+}
+
+// RegisterInvariant registers check as an invariant of your program, which is asserted to hold every time it is evaluated, as if by Always(condition, message, details) where check returns condition and details. The corresponding test property is reported where RegisterInvariant is called.
+//
+// Registered invariants are evaluated when CheckInvariants is called, periodically once SetInvariantInterval has been called, and each time lifecycle.SetupComplete or lifecycle.SendEvent is called. Each call to RegisterInvariant registers another invariant, even if it uses a message that has been registered before.
+func RegisterInvariant(message string, check func() (bool, map[string]any)) {
+	if check == nil {
+		return
+	}
+	loc := newLocationInfo(offsetAPICaller)
+	inv := &invariant{
+		check:   check,
+		loc:     loc,
+		message: message,
+		id:      makeKey(message, loc),
+	}
+	invariantsMutex.Lock()
+	defer invariantsMutex.Unlock()
+	invariants = append(invariants, inv)
+}
+
+// CheckInvariants evaluates every invariant registered with RegisterInvariant, in the order they were registered. When it is called by an invariant, directly or through lifecycle.SendEvent, it returns without evaluating the invariants again. Calls from other goroutines wait for the evaluation in progress to finish, and then evaluate the invariants.
+func CheckInvariants() {
+	invariantsMutex.Lock()
+	registered := make([]*invariant, len(invariants))
+	copy(registered, invariants)
+	invariantsMutex.Unlock()
+
+	if len(registered) == 0 {
+		return
+	}
+
+	// An invariant which calls CheckInvariants, or lifecycle.SendEvent, would
+	// otherwise wait for the evaluation it is part of. If the goroutine can
+	// not be identified, a nested call can not be told apart from another
+	// goroutine, and the call is skipped while an evaluation is in progress.
+	goroutine, ok := currentGoroutineId()
+	if ok && invariantCheckGoroutine.Load() == goroutine {
+		return
+	}
+	if ok {
+		invariantCheckMutex.Lock()
+	} else if !invariantCheckMutex.TryLock() {
+		return
+	}
+	defer invariantCheckMutex.Unlock()
+	if ok {
+		invariantCheckGoroutine.Store(goroutine)
+		defer invariantCheckGoroutine.Store(0)
+	}
+	for _, inv := range registered {
+		condition, details := inv.check()
+		loc := *inv.loc
+		assertImpl(condition, inv.message, details, &loc, wasHit, mustBeHit, universalTest, alwaysDisplay, inv.id)
+	}
+}
+
+// SetInvariantInterval evaluates the registered invariants, as CheckInvariants does, every interval from now on. An interval of zero or less stops the periodic evaluation. By default invariants are not evaluated periodically.
+func SetInvariantInterval(interval time.Duration) {
+	invariantsMutex.Lock()
+	defer invariantsMutex.Unlock()
+
+	if invariantStop != nil {
+		close(invariantStop)
+		invariantStop = nil
+	}
+	if interval <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	invariantStop = stop
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				CheckInvariants()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
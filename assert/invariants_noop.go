@@ -0,0 +1,9 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+import "time"
+
+func RegisterInvariant(message string, check func() (bool, map[string]any)) {}
+func CheckInvariants()                                                      {}
+func SetInvariantInterval(interval time.Duration)                           {}
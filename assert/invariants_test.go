@@ -0,0 +1,136 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/lifecycle"
+)
+
+// clearInvariants sets aside the registered invariants until the end of the test
+func clearInvariants(t *testing.T) {
+	resetTrackers()
+	invariantsMutex.Lock()
+	saved := invariants
+	invariants = nil
+	invariantsMutex.Unlock()
+	t.Cleanup(func() {
+		SetInvariantInterval(0)
+		invariantsMutex.Lock()
+		invariants = saved
+		invariantsMutex.Unlock()
+		resetTrackers()
+	})
+}
+
+func TestInvariants(t *testing.T) {
+	clearInvariants(t)
+
+	var evaluations atomic.Int64
+	RegisterInvariant("balance is conserved", func() (bool, map[string]any) {
+		n := evaluations.Add(1)
+		return n != 2, map[string]any{"evaluation": n}
+	})
+
+	CheckInvariants()
+	lifecycle.SendEvent("checkpoint", nil)
+	status := Snapshot()
+	if len(status) != 1 || status[0].PassCount != 1 || status[0].FailCount != 1 {
+		t.Fatalf("unexpected status after two evaluations: %+v", status)
+	}
+
+	SetInvariantInterval(time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for evaluations.Load() < 5 {
+		if time.Now().After(deadline) {
+			t.Fatalf("invariants were not evaluated periodically")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestInvariantsOnSetupComplete(t *testing.T) {
+	clearInvariants(t)
+
+	var evaluations atomic.Int64
+	RegisterInvariant("setup invariant", func() (bool, map[string]any) {
+		evaluations.Add(1)
+		return true, nil
+	})
+	lifecycle.SetupComplete(nil)
+	if evaluations.Load() != 1 {
+		t.Fatalf("expected one evaluation when setup completes, got %d", evaluations.Load())
+	}
+}
+
+func TestReentrantInvariant(t *testing.T) {
+	clearInvariants(t)
+
+	var evaluations atomic.Int64
+	RegisterInvariant("reentrant invariant", func() (bool, map[string]any) {
+		evaluations.Add(1)
+		lifecycle.SendEvent("checked", nil)
+		CheckInvariants()
+		return true, nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		CheckInvariants()
+		lifecycle.SendEvent("checkpoint", nil)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("an invariant sending an event deadlocked")
+	}
+	if evaluations.Load() != 2 {
+		t.Fatalf("expected the nested evaluations to be skipped, got %d evaluations", evaluations.Load())
+	}
+}
+
+func TestConcurrentInvariantChecks(t *testing.T) {
+	clearInvariants(t)
+
+	var evaluations atomic.Int64
+	started := make(chan struct{})
+	release := make(chan struct{})
+	RegisterInvariant("slow invariant", func() (bool, map[string]any) {
+		if evaluations.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		return true, nil
+	})
+
+	first := make(chan struct{})
+	go func() {
+		defer close(first)
+		CheckInvariants()
+	}()
+	<-started
+
+	// A milestone reported by another goroutine waits for the
+	// evaluation in progress rather than being dropped
+	second := make(chan struct{})
+	go func() {
+		defer close(second)
+		lifecycle.SendEvent("checkpoint", nil)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	for _, done := range []chan struct{}{first, second} {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("concurrent invariant checks deadlocked")
+		}
+	}
+	if evaluations.Load() != 2 {
+		t.Fatalf("expected both checks to evaluate the invariant, got %d evaluations", evaluations.Load())
+	}
+}
@@ -3,9 +3,11 @@
 package assert
 
 import (
-	"path"
 	"runtime"
+	"runtime/debug"
 	"strings"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal/callsite"
 )
 
 // stackFrameOffset indicates how many frames to go up in the
@@ -27,6 +29,7 @@ const (
 type locationInfo struct {
 	Classname string `json:"class"`
 	Funcname  string `json:"function"`
+	Receiver  string `json:"receiver,omitempty"`
 	Filename  string `json:"file"`
 	Line      int    `json:"begin_line"`
 	Column    int    `json:"begin_column"`
@@ -48,11 +51,43 @@ func newLocationInfo(nframes stackFrameOffset) *locationInfo {
 		line = 0
 	} else {
 		if this_func := runtime.FuncForPC(pc); this_func != nil {
-			fullname := this_func.Name()
-			funcname = path.Ext(fullname)
-			classname, _ = strings.CutSuffix(fullname, funcname)
-			funcname = funcname[1:]
+			classname, funcname = callsite.SplitFuncName(this_func.Name())
 		}
 	}
-	return &locationInfo{classname, funcname, filename, line, columnUnknown}
+	return &locationInfo{Classname: classname, Funcname: funcname, Filename: filename, Line: line, Column: columnUnknown}
+}
+
+// packagePath finds the import path of a package from a class name
+// such as "example.com/pkg.(*Server)", naming the main package by its
+// import path as well
+func packagePath(classname string) string {
+	slash := strings.LastIndex(classname, "/")
+	if dot := strings.Index(classname[slash+1:], "."); dot != -1 {
+		classname = classname[:slash+1+dot]
+	}
+	if classname == "main" {
+		return mainPackagePath
+	}
+	return classname
+}
+
+var mainPackagePath = readMainPackagePath()
+
+func readMainPackagePath() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Path != "" {
+		return info.Path
+	}
+	return "main"
+}
+
+// locationInfoFrom creates a locationInfo for a Location given by the caller
+func locationInfoFrom(loc Location) *locationInfo {
+	return &locationInfo{
+		Classname: loc.Classname,
+		Funcname:  loc.Funcname,
+		Receiver:  loc.Receiver,
+		Filename:  loc.Filename,
+		Line:      loc.Line,
+		Column:    loc.Column,
+	}
 }
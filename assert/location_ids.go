@@ -0,0 +1,7 @@
+//go:build !no_antithesis_sdk && antithesis_location_ids
+
+package assert
+
+// Built with the antithesis_location_ids tag: assertions are identified
+// by their message together with their file and line.
+const useLocationIds = true
@@ -0,0 +1,6 @@
+//go:build !no_antithesis_sdk && !antithesis_location_ids
+
+package assert
+
+// Assertions are identified by their message alone.
+const useLocationIds = false
@@ -0,0 +1,73 @@
+//go:build !no_antithesis_sdk && antithesis_location_ids
+
+package assert
+
+import (
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal/locationkey"
+)
+
+func TestLocationIds(t *testing.T) {
+	loc := &locationInfo{Classname: "example.com/server.(*Server)", Filename: "/src/example.com/server/server.go", Line: 12}
+	if id := makeKey("served", loc); id != "served [example.com/server/server.go:12]" {
+		t.Fatalf("unexpected id %q", id)
+	}
+	loc = &locationInfo{Classname: "example.com/internal/server", Filename: "/src/example.com/internal/server/server.go", Line: 12}
+	if id := makeKey("served", loc); id != "served [example.com/internal/server/server.go:12]" {
+		t.Fatalf("expected the import path to distinguish files of the same name, got %q", id)
+	}
+	if id := makeKey("served", nil); id != "served" {
+		t.Fatalf("expected the message as the id without a location, got %q", id)
+	}
+
+	output := captureOutput(t)
+	Always(true, "reused message", nil)
+	Always(false, "reused message", nil)
+	assertions := output.assertions()
+	if len(assertions) != 2 || assertions[0].Id == assertions[1].Id {
+		t.Fatalf("expected distinct properties for each call site, got %+v", assertions)
+	}
+	for _, assertion := range assertions {
+		if assertion.Id != locationkey.Key(assertion.Message, "github.com/antithesishq/antithesis-sdk-go/assert", assertion.Location.Filename, assertion.Location.Line) {
+			t.Fatalf("unexpected id %q for %+v", assertion.Id, assertion.Location)
+		}
+	}
+	if diagnostics := output.count(diagnosticPrefix); diagnostics != 0 {
+		t.Fatalf("expected no diagnostic for assertions of the same type, got %d", diagnostics)
+	}
+}
+
+func TestConflictingAssertionTypes(t *testing.T) {
+	output := captureOutput(t)
+	Always(true, "conflicting message", nil)
+	Sometimes(true, "conflicting message", nil)
+	Reachable("conflicting message", nil)
+
+	diagnostics := output.records(diagnosticPrefix)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected one diagnostic for the message, got %q", diagnostics)
+	}
+	var record struct {
+		Diagnostic struct {
+			Kind    string `json:"kind"`
+			Message string `json:"message"`
+			First   struct {
+				AssertType string       `json:"assert_type"`
+				Location   locationInfo `json:"location"`
+			} `json:"first"`
+			Conflicting struct {
+				AssertType string       `json:"assert_type"`
+				Location   locationInfo `json:"location"`
+			} `json:"conflicting"`
+		} `json:"antithesis_sdk_diagnostic"`
+	}
+	output.decode(diagnostics[0], &record)
+	diagnostic := record.Diagnostic
+	if diagnostic.Kind != "conflicting_assertion_types" || diagnostic.Message != "conflicting message" {
+		t.Fatalf("unexpected diagnostic %s", diagnostics[0])
+	}
+	if diagnostic.First.AssertType != universalTest || diagnostic.Conflicting.AssertType != existentialTest || diagnostic.Conflicting.Location.Line != diagnostic.First.Location.Line+1 {
+		t.Fatalf("expected the first and the conflicting assertions, got %s", diagnostics[0])
+	}
+}
@@ -4,7 +4,9 @@ package assert
 
 import (
 	"math"
+	"reflect"
 	"sync"
+	"sync/atomic"
 
 	"github.com/antithesishq/antithesis-sdk-go/internal"
 )
@@ -27,8 +29,8 @@ const (
 func gapTypeForOperand[T Number](num T) numericGapType {
 	gapType := integerGapType
 
-	switch any(num).(type) {
-	case float32, float64:
+	switch reflect.ValueOf(num).Kind() {
+	case reflect.Float32, reflect.Float64:
 		gapType = floatGapType
 	}
 	return gapType
@@ -45,35 +47,29 @@ func gapTypeForOperand[T Number](num T) numericGapType {
 //
 // --------------------------------------------------------------------------------
 type numericGuidanceInfo struct {
-	gap           any
+	mutex         sync.Mutex   // serializes updates of gap
+	gap           atomic.Value // gapValue[uint64] or gapValue[float64]
 	descriminator numericGapType
 	maximize      bool
 }
 
-type numericGuidanceTracker map[string]*numericGuidanceInfo
-
-var (
-	numeric_guidance_tracker       numericGuidanceTracker = make(numericGuidanceTracker)
-	numeric_guidance_tracker_mutex sync.Mutex
-	numeric_guidance_info_mutex    sync.Mutex
-)
+// numericGuidanceTracker maps the id of each guidance to its *numericGuidanceInfo
+type numericGuidanceTracker struct {
+	entries sync.Map
+}
 
-func (tracker numericGuidanceTracker) getTrackerEntry(messageKey string, trackerType numericGapType, maximize bool) *numericGuidanceInfo {
-	var trackerEntry *numericGuidanceInfo
-	var ok bool
+var numeric_guidance_tracker = &numericGuidanceTracker{}
 
+func (tracker *numericGuidanceTracker) getTrackerEntry(messageKey string, trackerType numericGapType, maximize bool) *numericGuidanceInfo {
 	if tracker == nil {
 		return nil
 	}
 
-	numeric_guidance_tracker_mutex.Lock()
-	defer numeric_guidance_tracker_mutex.Unlock()
-	if trackerEntry, ok = numeric_guidance_tracker[messageKey]; !ok {
-		trackerEntry = newNumericGuidanceInfo(trackerType, maximize)
-		tracker[messageKey] = trackerEntry
+	if entry, ok := tracker.entries.Load(messageKey); ok {
+		return entry.(*numericGuidanceInfo)
 	}
-
-	return trackerEntry
+	entry, _ := tracker.entries.LoadOrStore(messageKey, newNumericGuidanceInfo(trackerType, maximize))
+	return entry.(*numericGuidanceInfo)
 }
 
 // Create an numeric guidance entry
@@ -88,8 +84,8 @@ func newNumericGuidanceInfo(trackerType numericGapType, maximize bool) *numericG
 	trackerInfo := numericGuidanceInfo{
 		maximize:      maximize,
 		descriminator: trackerType,
-		gap:           gap,
 	}
+	trackerInfo.gap.Store(gap)
 	return &trackerInfo
 }
 
@@ -180,9 +176,6 @@ func send_value_if_needed(tI *numericGuidanceInfo, gI *guidanceInfo) {
 		return
 	}
 
-	numeric_guidance_info_mutex.Lock()
-	defer numeric_guidance_info_mutex.Unlock()
-
 	// if this is a catalog entry (gI.hit is false)
 	// do not update the reference gap in the tracker (tI *numericGuidanceInfo)
 	if !gI.Hit {
@@ -190,12 +183,7 @@ func send_value_if_needed(tI *numericGuidanceInfo, gI *guidanceInfo) {
 		return
 	}
 
-	should_send := false
-	maximize := tI.should_maximize()
-
-	var gap gapValue[uint64]
-	var float_gap gapValue[float64]
-
+	var gap any
 	// Needs to have individual case statements to assist
 	// the compiler to infer the actual type of the var named 'operands'
 	switch operands := (gI.Data).(type) {
@@ -206,46 +194,84 @@ func send_value_if_needed(tI *numericGuidanceInfo, gI *guidanceInfo) {
 	case numericOperands[uint64]:
 		gap = makeGap(operands)
 	case numericOperands[float64]:
-		float_gap = makeFloatGap(operands)
+		gap = makeFloatGap(operands)
+	}
+	if tI.is_integer_gap() {
+		if _, ok := gap.(gapValue[uint64]); !ok {
+			gap = gapValue[uint64]{}
+		}
+	} else if _, ok := gap.(gapValue[float64]); !ok {
+		gap = gapValue[float64]{}
 	}
 
-	var prev_gap gapValue[uint64]
-	var prev_float_gap gapValue[float64]
-	has_prev_gap := false
-	has_prev_float_gap := false
+	// Most values are no improvement on the gap already sent,
+	// and are discarded without taking the lock
+	if !tI.improves_on(gap, tI.gap.Load()) {
+		return
+	}
 
-	prev_gap, has_prev_gap = tI.gap.(gapValue[uint64])
-	if !has_prev_gap {
-		prev_float_gap, has_prev_float_gap = tI.gap.(gapValue[float64])
+	tI.mutex.Lock()
+	defer tI.mutex.Unlock()
+	if tI.improves_on(gap, tI.gap.Load()) {
+		tI.gap.Store(gap)
+		emitGuidance(gI)
 	}
+}
 
-	if has_prev_gap {
+// improves_on reports whether gap is further in the direction of the
+// guidance than prev_gap. Both have the gap type of the tracker entry.
+func (tI *numericGuidanceInfo) improves_on(gap, prev_gap any) bool {
+	maximize := tI.should_maximize()
+	switch prev := prev_gap.(type) {
+	case gapValue[uint64]:
 		if maximize {
-			should_send = is_greater_than(gap, prev_gap)
-		} else {
-			should_send = is_less_than(gap, prev_gap)
+			return is_greater_than(gap.(gapValue[uint64]), prev)
 		}
-	}
-
-	if has_prev_float_gap {
+		return is_less_than(gap.(gapValue[uint64]), prev)
+	case gapValue[float64]:
 		if maximize {
-			should_send = is_greater_than(float_gap, prev_float_gap)
-		} else {
-			should_send = is_less_than(float_gap, prev_float_gap)
+			return is_greater_than(gap.(gapValue[float64]), prev)
 		}
+		return is_less_than(gap.(gapValue[float64]), prev)
 	}
+	return false
+}
 
-	if should_send {
-		if tI.is_integer_gap() {
-			tI.gap = gap
-		} else {
-			tI.gap = float_gap
-		}
-		emitGuidance(gI)
+// guidanceLocation holds the file and class names of guidance when it was
+// first seen, which is its registration when a catalog is linked in
+type guidanceLocation struct {
+	Filename  string
+	Classname string
+}
+
+// guidanceLocationTracker maps the id of each guidance to its *guidanceLocation
+type guidanceLocationTracker struct {
+	entries sync.Map
+}
+
+var guidance_location_tracker = &guidanceLocationTracker{}
+
+// normalize gives loc the file and class names captured when the guidance
+// with this id was first seen, as trackAssertInfo does for assertions, so
+// that guidance sent at runtime is reported at the location registered by
+// the catalog rather than at the absolute path of its file
+func (tracker *guidanceLocationTracker) normalize(id string, loc *locationInfo) {
+	if loc == nil {
+		return
 	}
+	entry, ok := tracker.entries.Load(id)
+	if !ok {
+		entry, _ = tracker.entries.LoadOrStore(id, &guidanceLocation{Filename: loc.Filename, Classname: loc.Classname})
+	}
+	first := entry.(*guidanceLocation)
+	loc.Filename = first.Filename
+	loc.Classname = first.Classname
 }
 
 func emitGuidance(gI *guidanceInfo) error {
+	if gI.Hit {
+		gI.Details = goroutineContext()
+	}
 	return internal.Json_data(map[string]any{"antithesis_guidance": gI})
 }
 
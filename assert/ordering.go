@@ -0,0 +1,256 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"container/list"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Bounds on the state kept for events. Once maxEventKeys keys are being
+// tracked, recording an event for a new key forgets the oldest key. The
+// last maxEventKeys keys forgotten this way are remembered, so that rules
+// are not checked against their incomplete events. Distinct event names
+// beyond maxEventsPerKey are not recorded for a key, unless they are the
+// first event of a rule.
+const (
+	maxEventKeys    = 10000
+	maxEventsPerKey = 64
+)
+
+// orderingRule is declared by HappensBefore or NeverAfter, and is
+// evaluated each time its second event is recorded for a key.
+type orderingRule struct {
+	loc     *locationInfo
+	first   string
+	second  string
+	message string
+	id      string
+	never   bool
+}
+
+// keyEvents holds the events recorded for a key. Its mutex only
+// serializes the events of that key.
+type keyEvents struct {
+	mutex     sync.Mutex
+	element   *list.Element
+	seen      map[string]bool
+	names     []string
+	truncated bool // the key was forgotten before these events
+}
+
+// orderingRuleSet holds the rules declared so far
+type orderingRuleSet struct {
+	bySecond map[string][]*orderingRule
+	firsts   map[string]bool
+}
+
+// The rules are never modified once they are published, so that Event can
+// read them without a lock. Declarations are serialized by rulesMutex.
+//
+// Keys already tracked are found in eventKeys without a lock. Only adding
+// or forgetting a key takes eventKeysMutex, which guards eventKeyOrder.
+var (
+	orderingRules   atomic.Pointer[orderingRuleSet]
+	declaredRules   = map[orderingRule]bool{}
+	rulesMutex      sync.Mutex
+	eventKeys       sync.Map     // key -> *keyEvents
+	eventKeyOrder   = list.New() // oldest key first
+	evictedKeys     = map[any]*list.Element{}
+	evictedKeyOrder = list.New() // oldest forgotten key first
+	eventKeysFull   bool
+	eventKeysMutex  sync.Mutex
+)
+
+// HappensBefore declares that, for each key, the event named second is only ever recorded after the event named first has been recorded for the same key. Each time second is recorded with Event, this is checked as an Always property named message. Declaring the same rule more than once has no further effect.
+//
+// Rules should be declared before the events they refer to are recorded, for example in an init function.
+func HappensBefore(first, second, message string) {
+	loc := newLocationInfo(offsetAPICaller)
+	declareOrderingRule(orderingRule{
+		loc:     loc,
+		first:   first,
+		second:  second,
+		message: message,
+		id:      makeKey(message, loc),
+	})
+}
+
+// NeverAfter declares that, for each key, the event named second is never recorded after the event named first has been recorded for the same key. Each time second is recorded with Event, this is checked as an Always property named message. Declaring the same rule more than once has no further effect.
+func NeverAfter(first, second, message string) {
+	loc := newLocationInfo(offsetAPICaller)
+	declareOrderingRule(orderingRule{
+		loc:     loc,
+		first:   first,
+		second:  second,
+		message: message,
+		id:      makeKey(message, loc),
+		never:   true,
+	})
+}
+
+func declareOrderingRule(rule orderingRule) {
+	// Rules are told apart by everything except where they were declared
+	key := rule
+	key.loc = nil
+
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+	if declaredRules[key] {
+		return
+	}
+	declaredRules[key] = true
+
+	rules := &orderingRuleSet{bySecond: map[string][]*orderingRule{}, firsts: map[string]bool{}}
+	if current := orderingRules.Load(); current != nil {
+		for second, declared := range current.bySecond {
+			rules.bySecond[second] = declared
+		}
+		for first := range current.firsts {
+			rules.firsts[first] = true
+		}
+	}
+	rules.bySecond[rule.second] = append(append([]*orderingRule{}, rules.bySecond[rule.second]...), &rule)
+	rules.firsts[rule.first] = true
+	orderingRules.Store(rules)
+}
+
+func rulesFor(second string) []*orderingRule {
+	if rules := orderingRules.Load(); rules != nil {
+		return rules.bySecond[second]
+	}
+	return nil
+}
+
+func isRuleFirst(name string) bool {
+	if rules := orderingRules.Load(); rules != nil {
+		return rules.firsts[name]
+	}
+	return false
+}
+
+// Event records that the event named name happened for key, such as a transaction id, and checks every rule declared by HappensBefore and NeverAfter whose second event is name. The key must be usable as a map key; other keys are identified by their text as formatted with the %v verb.
+//
+// At most 10000 keys are remembered at a time, after which the oldest key is forgotten. Until its first event is recorded again, a rule is not checked for a key which was forgotten this way, since the events recorded before are not known. Call EventsComplete once no more events will be recorded for a key, so that the state kept for it can be reclaimed.
+func Event(key any, name string) {
+	if key != nil && !reflect.TypeOf(key).Comparable() {
+		key = fmt.Sprintf("%v", key)
+	}
+
+	rules := rulesFor(name)
+	events, evicted := eventsForKey(key)
+	events.mutex.Lock()
+	checked := make([]bool, len(rules))
+	conditions := make([]bool, len(rules))
+	for i, rule := range rules {
+		seen := events.seen[rule.first]
+		checked[i] = seen || !events.truncated
+		conditions[i] = seen != rule.never
+	}
+	var history []string
+	if len(rules) > 0 {
+		history = append([]string{}, events.names...)
+	}
+	if !events.seen[name] {
+		if len(events.names) < maxEventsPerKey {
+			events.seen[name] = true
+			events.names = append(events.names, name)
+		} else if isRuleFirst(name) {
+			// Rules must not depend on the bound on the history
+			events.seen[name] = true
+		}
+	}
+	events.mutex.Unlock()
+
+	if evicted {
+		emitDiagnostic(map[string]any{
+			"kind":     "event_key_limit_exceeded",
+			"max_keys": maxEventKeys,
+		})
+	}
+	for i, rule := range rules {
+		if !checked[i] {
+			continue
+		}
+		details := map[string]any{
+			"key":    fmt.Sprintf("%v", key),
+			"event":  name,
+			"first":  rule.first,
+			"events": history,
+		}
+		ruleLoc := *rule.loc
+		assertImpl(conditions[i], rule.message, details, &ruleLoc, wasHit, mustBeHit, universalTest, alwaysDisplay, rule.id)
+	}
+}
+
+// EventsComplete forgets the events recorded for key. Events recorded for key afterwards are checked as if key had never been seen.
+func EventsComplete(key any) {
+	if key != nil && !reflect.TypeOf(key).Comparable() {
+		key = fmt.Sprintf("%v", key)
+	}
+
+	eventKeysMutex.Lock()
+	defer eventKeysMutex.Unlock()
+	if events, ok := eventKeys.LoadAndDelete(key); ok {
+		eventKeyOrder.Remove(events.(*keyEvents).element)
+	}
+	if evicted, ok := evictedKeys[key]; ok {
+		evictedKeyOrder.Remove(evicted)
+		delete(evictedKeys, key)
+	}
+}
+
+// eventsForKey finds the events of key, adding the key if it is new. It
+// reports whether a key was forgotten to make room for the first time.
+func eventsForKey(key any) (*keyEvents, bool) {
+	if events, ok := eventKeys.Load(key); ok {
+		return events.(*keyEvents), false
+	}
+
+	eventKeysMutex.Lock()
+	defer eventKeysMutex.Unlock()
+	if events, ok := eventKeys.Load(key); ok {
+		return events.(*keyEvents), false
+	}
+	firstEviction := false
+	if eventKeyOrder.Len() >= maxEventKeys {
+		oldest := eventKeyOrder.Front()
+		eventKeyOrder.Remove(oldest)
+		eventKeys.Delete(oldest.Value)
+		rememberEvictedKey(oldest.Value)
+		firstEviction = !eventKeysFull
+		eventKeysFull = true
+	}
+	events := &keyEvents{seen: map[string]bool{}}
+	if evicted, ok := evictedKeys[key]; ok {
+		evictedKeyOrder.Remove(evicted)
+		delete(evictedKeys, key)
+		events.truncated = true
+	}
+	events.element = eventKeyOrder.PushBack(key)
+	eventKeys.Store(key, events)
+	return events, firstEviction
+}
+
+// rememberEvictedKey must be called with eventKeysMutex held
+func rememberEvictedKey(key any) {
+	if evictedKeyOrder.Len() >= maxEventKeys {
+		oldest := evictedKeyOrder.Front()
+		evictedKeyOrder.Remove(oldest)
+		delete(evictedKeys, oldest.Value)
+	}
+	evictedKeys[key] = evictedKeyOrder.PushBack(key)
+}
+
+func resetEvents() {
+	eventKeysMutex.Lock()
+	defer eventKeysMutex.Unlock()
+	clearSyncMap(&eventKeys)
+	eventKeyOrder.Init()
+	evictedKeys = map[any]*list.Element{}
+	evictedKeyOrder.Init()
+	eventKeysFull = false
+}
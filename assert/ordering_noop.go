@@ -0,0 +1,8 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+func HappensBefore(first, second, message string) {}
+func NeverAfter(first, second, message string)    {}
+func Event(key any, name string)                  {}
+func EventsComplete(key any)                      {}
@@ -0,0 +1,99 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOrderingRules(t *testing.T) {
+	resetTrackers()
+	defer resetTrackers()
+
+	for i := 0; i < 2; i++ {
+		HappensBefore("prepare", "commit", "commit follows prepare")
+	}
+	NeverAfter("abort", "commit", "no commit after abort")
+
+	Event("txn-1", "prepare")
+	Event("txn-1", "commit")
+	Event([]int{2}, "commit")
+	Event("txn-3", "abort")
+	Event("txn-3", "prepare")
+	Event("txn-3", "commit")
+	EventsComplete("txn-3")
+	Event("txn-3", "prepare")
+	Event("txn-3", "commit")
+
+	counts := map[string][2]int{}
+	for _, status := range Snapshot() {
+		counts[status.Message] = [2]int{status.PassCount, status.FailCount}
+	}
+	if counts["commit follows prepare"] != [2]int{3, 1} {
+		t.Fatalf("unexpected counts for HappensBefore: %v", counts["commit follows prepare"])
+	}
+	if counts["no commit after abort"] != [2]int{3, 1} {
+		t.Fatalf("unexpected counts for NeverAfter: %v", counts["no commit after abort"])
+	}
+	if rules := rulesFor("commit"); len(rules) != 2 {
+		t.Fatalf("expected repeated declarations to be ignored, got %d rules", len(rules))
+	}
+}
+
+func TestEventKeyLimit(t *testing.T) {
+	resetTrackers()
+	defer resetTrackers()
+
+	for i := 0; i < maxEventKeys+5; i++ {
+		Event(i, "tick")
+	}
+	keys := 0
+	eventKeys.Range(func(_, _ any) bool {
+		keys++
+		return true
+	})
+	if keys != maxEventKeys || eventKeyOrder.Len() != maxEventKeys {
+		t.Fatalf("expected %d keys, got %d", maxEventKeys, keys)
+	}
+	if _, ok := eventKeys.Load(0); ok {
+		t.Fatalf("expected the oldest key to be forgotten")
+	}
+}
+
+func TestOrderingBeyondLimits(t *testing.T) {
+	resetTrackers()
+	defer resetTrackers()
+
+	HappensBefore("limits prepare", "limits commit", "commit follows prepare beyond limits")
+	NeverAfter("limits abort", "limits commit", "no commit after abort beyond limits")
+
+	// The first events of rules are recorded beyond the bound on names
+	for i := 0; i < maxEventsPerKey+5; i++ {
+		Event("txn-many", fmt.Sprintf("step %d", i))
+	}
+	Event("txn-many", "limits prepare")
+	Event("txn-many", "limits abort")
+	Event("txn-many", "limits commit")
+
+	// A key which was forgotten is not checked until its first event is
+	// recorded again
+	Event("txn-evicted", "limits prepare")
+	for i := 0; i < maxEventKeys; i++ {
+		Event(i, "limits tick")
+	}
+	Event("txn-evicted", "limits commit")
+	Event("txn-evicted", "limits prepare")
+	Event("txn-evicted", "limits commit")
+
+	counts := map[string][2]int{}
+	for _, status := range Snapshot() {
+		counts[status.Message] = [2]int{status.PassCount, status.FailCount}
+	}
+	if counts["commit follows prepare beyond limits"] != [2]int{2, 0} {
+		t.Fatalf("unexpected counts for HappensBefore: %v", counts["commit follows prepare beyond limits"])
+	}
+	if counts["no commit after abort beyond limits"] != [2]int{0, 1} {
+		t.Fatalf("unexpected counts for NeverAfter: %v", counts["no commit after abort beyond limits"])
+	}
+}
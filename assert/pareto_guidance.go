@@ -0,0 +1,161 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// maxParetoPoints bounds the number of points remembered on the frontier of
+// each Pareto guidance. Once it is reached, points which are not dominated
+// by the frontier are still sent, but are no longer remembered.
+const maxParetoPoints = 256
+
+// paretoGuidance remembers the frontier of the points already sent: the
+// points which no other point sent is at least as interesting as in every
+// dimension. The frontier is replaced, rather than modified, so that
+// dominated points are discarded without a lock; mutex serializes updates.
+type paretoGuidance struct {
+	mutex    sync.Mutex
+	frontier atomic.Pointer[[][]Dimension]
+}
+
+// paretoGuidanceTracker maps the id of each guidance to its *paretoGuidance
+type paretoGuidanceTracker struct {
+	entries sync.Map
+}
+
+var pareto_guidance_tracker = &paretoGuidanceTracker{}
+
+func (tracker *paretoGuidanceTracker) getTrackerEntry(messageKey string) *paretoGuidance {
+	if entry, ok := tracker.entries.Load(messageKey); ok {
+		return entry.(*paretoGuidance)
+	}
+	entry, _ := tracker.entries.LoadOrStore(messageKey, &paretoGuidance{})
+	return entry.(*paretoGuidance)
+}
+
+// is_improvement records point on the frontier and reports whether it is
+// not dominated by a point already sent
+func (tI *paretoGuidance) is_improvement(point []Dimension) bool {
+	// Most points are dominated by the frontier,
+	// and are discarded without taking the lock
+	if tI.is_dominated(point) {
+		return false
+	}
+
+	tI.mutex.Lock()
+	defer tI.mutex.Unlock()
+	if tI.is_dominated(point) {
+		return false
+	}
+	var current [][]Dimension
+	if loaded := tI.frontier.Load(); loaded != nil {
+		current = *loaded
+	}
+	frontier := make([][]Dimension, 0, len(current)+1)
+	for _, seen := range current {
+		if !dominates(point, seen) {
+			frontier = append(frontier, seen)
+		}
+	}
+	if len(frontier) < maxParetoPoints {
+		frontier = append(frontier, point)
+	}
+	tI.frontier.Store(&frontier)
+	return true
+}
+
+func (tI *paretoGuidance) is_dominated(point []Dimension) bool {
+	frontier := tI.frontier.Load()
+	if frontier == nil {
+		return false
+	}
+	for _, seen := range *frontier {
+		if dominates(seen, point) {
+			return true
+		}
+	}
+	return false
+}
+
+// dominates reports whether a is at least as interesting as b in every
+// dimension. Points with different dimensions do not dominate each other.
+func dominates(a, b []Dimension) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Maximize != b[i].Maximize {
+			return false
+		}
+		if a[i].Maximize && a[i].Value < b[i].Value {
+			return false
+		}
+		if !a[i].Maximize && a[i].Value > b[i].Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Pareto tells Antithesis to favor explorations where the named values of dimensions are, taken together, more interesting than any seen before, without asserting anything about them. Use it when no single value makes a state interesting, such as a state with both high load and little free disk space.
+//
+// A point is only sent if no point reported before with the same message is at least as interesting in every dimension. Points are compared dimension by dimension, so every call with the same message should list the same dimensions in the same order. Points with a NaN or infinite value are ignored, since they cannot be sent. At most 256 points are remembered for each message.
+func Pareto(message string, dimensions []Dimension) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	paretoGuidanceImpl(dimensions, message, id, loc, wasHit)
+}
+
+func paretoGuidanceImpl(dimensions []Dimension, message, id string, loc *locationInfo, hit bool) {
+	guidance_location_tracker.normalize(id, loc)
+	gI := &guidanceInfo{
+		GuidanceType: get_guidance_type_string(guidanceFnPareto),
+		Message:      message,
+		Id:           id,
+		Location:     loc,
+		Maximize:     uses_maximize(guidanceFnPareto),
+		Hit:          hit,
+	}
+
+	// Registrations carry no point
+	if !hit {
+		emitGuidance(gI)
+		return
+	}
+
+	if len(dimensions) == 0 {
+		return
+	}
+	for _, dimension := range dimensions {
+		if math.IsNaN(dimension.Value) || math.IsInf(dimension.Value, 0) {
+			return
+		}
+	}
+	point := append([]Dimension{}, dimensions...)
+	if !pareto_guidance_tracker.getTrackerEntry(id).is_improvement(point) {
+		return
+	}
+	gI.Data = point
+	emitGuidance(gI)
+}
+
+// ParetoGuidanceRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it. New frameworks should prefer EmitParetoGuidance.
+func ParetoGuidanceRaw(
+	dimensions []Dimension,
+	message, id string,
+	classname, funcname, filename string,
+	line int,
+	hit bool,
+) {
+	EmitParetoGuidance(rawGuidance(message, id, classname, funcname, filename, line, "", hit), dimensions)
+}
+
+// EmitParetoGuidance is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+func EmitParetoGuidance(guidance RawGuidance, dimensions []Dimension) {
+	loc := locationInfoFrom(guidance.Location)
+	paretoGuidanceImpl(dimensions, guidance.Message, guidance.Id, loc, guidance.Hit)
+}
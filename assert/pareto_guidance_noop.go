@@ -0,0 +1,16 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+func Pareto(message string, dimensions []Dimension) {}
+
+func ParetoGuidanceRaw(
+	dimensions []Dimension,
+	message, id string,
+	classname, funcname, filename string,
+	line int,
+	hit bool,
+) {
+}
+
+func EmitParetoGuidance(guidance RawGuidance, dimensions []Dimension) {}
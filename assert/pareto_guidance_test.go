@@ -0,0 +1,49 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPareto(t *testing.T) {
+	output := captureOutput(t)
+
+	point := func(load, freeDisk float64) []Dimension {
+		return []Dimension{MaximizeDimension("load", load), MinimizeDimension("free_disk", freeDisk)}
+	}
+	points := [][]Dimension{
+		point(10, 500),
+		point(10, 500),        // same point
+		point(5, 600),         // dominated
+		point(20, 800),        // higher load, more disk
+		point(2, 100),         // lower load, less disk
+		point(30, 50),         // dominates every point so far
+		point(25, 60),         // dominated by the last point
+		point(math.NaN(), 0),  // ignored
+		point(math.Inf(1), 0), // ignored
+		point(40, math.Inf(-1)),
+		point(35, 40), // not hidden by the ignored points
+	}
+	for _, p := range points {
+		Pareto("load and disk", p)
+	}
+
+	sent := output.records(guidancePrefix)
+	if len(sent) != 5 {
+		t.Fatalf("expected only points not dominated to be sent, got %q", sent)
+	}
+	want := `"guidance_data":[{"name":"load","value":35,"maximize":true},{"name":"free_disk","value":40,"maximize":false}]`
+	if !strings.Contains(sent[4], want) || !strings.Contains(sent[4], `"guidance_type":"pareto"`) {
+		t.Fatalf("unexpected guidance: %s", sent[4])
+	}
+
+	pareto_guidance_tracker.entries.Range(func(_, entry any) bool {
+		if frontier := *entry.(*paretoGuidance).frontier.Load(); len(frontier) != 1 {
+			t.Fatalf("expected dominated points to leave the frontier, got %v", frontier)
+		}
+		return true
+	})
+}
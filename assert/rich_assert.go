@@ -2,6 +2,11 @@
 
 package assert
 
+import (
+	"reflect"
+	"time"
+)
+
 // A type for writing raw assertions.
 // guidanceFnType allows the assertion to provide guidance to
 // the Antithesis platform when testing in Antithesis.
@@ -13,11 +18,10 @@ const (
 	guidanceFnMinimize                       // Minimize (left - right) values
 	guidanceFnWantAll                        // Encourages fuzzing explorations where boolean values are true
 	guidanceFnWantNone                       // Encourages fuzzing explorations where boolean values are false
-	guidanceFnExplore
+	guidanceFnExplore                        // Encourages fuzzing explorations which reach states not seen before
+	guidanceFnPareto                         // Encourages fuzzing explorations which improve on a set of named values
 )
 
-// guidanceFnExplore
-
 func get_guidance_type_string(gt guidanceFnType) string {
 	switch gt {
 	case guidanceFnMaximize, guidanceFnMinimize:
@@ -26,6 +30,8 @@ func get_guidance_type_string(gt guidanceFnType) string {
 		return "boolean"
 	case guidanceFnExplore:
 		return "json"
+	case guidanceFnPareto:
+		return "pareto"
 	}
 	return ""
 }
@@ -36,23 +42,25 @@ type numericOperands[T operandConstraint] struct {
 }
 
 type guidanceInfo struct {
-	Data         any           `json:"guidance_data,omitempty"`
-	Location     *locationInfo `json:"location"`
-	GuidanceType string        `json:"guidance_type"`
-	Message      string        `json:"message"`
-	Id           string        `json:"id"`
-	Maximize     bool          `json:"maximize"`
-	Hit          bool          `json:"hit"`
+	Data         any            `json:"guidance_data,omitempty"`
+	Details      map[string]any `json:"details,omitempty"`
+	Location     *locationInfo  `json:"location"`
+	GuidanceType string         `json:"guidance_type"`
+	Message      string         `json:"message"`
+	Id           string         `json:"id"`
+	Maximize     bool           `json:"maximize"`
+	Hit          bool           `json:"hit"`
 }
 
 type booleanGuidanceInfo struct {
-	Data         any           `json:"guidance_data,omitempty"`
-	Location     *locationInfo `json:"location"`
-	GuidanceType string        `json:"guidance_type"`
-	Message      string        `json:"message"`
-	Id           string        `json:"id"`
-	Maximize     bool          `json:"maximize"`
-	Hit          bool          `json:"hit"`
+	Data         any            `json:"guidance_data,omitempty"`
+	Details      map[string]any `json:"details,omitempty"`
+	Location     *locationInfo  `json:"location"`
+	GuidanceType string         `json:"guidance_type"`
+	Message      string         `json:"message"`
+	Id           string         `json:"id"`
+	Maximize     bool           `json:"maximize"`
+	Hit          bool           `json:"hit"`
 }
 
 func uses_maximize(gt guidanceFnType) bool {
@@ -70,6 +78,18 @@ func newOperands[T Number](left, right T) any {
 	case float32, float64:
 		return numericOperands[float64]{float64(left), float64(right)}
 	}
+
+	// Types defined from a number type, such as time.Duration
+	switch reflect.ValueOf(left).Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return numericOperands[int32]{int32(left), int32(right)}
+	case reflect.Int, reflect.Int64:
+		return numericOperands[int64]{int64(left), int64(right)}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return numericOperands[uint64]{uint64(left), uint64(right)}
+	case reflect.Float32, reflect.Float64:
+		return numericOperands[float64]{float64(left), float64(right)}
+	}
 	return nil
 }
 
@@ -139,18 +159,40 @@ func behavior_to_guidance(behavior string) guidanceFnType {
 }
 
 func numericGuidanceImpl[T Number](left, right T, message, id string, loc *locationInfo, guidanceFn guidanceFnType, hit bool) {
+	guidance_location_tracker.normalize(id, loc)
 	tI := numeric_guidance_tracker.getTrackerEntry(id, gapTypeForOperand(left), uses_maximize(guidanceFn))
 	gI := build_numeric_guidance(guidanceFn, message, left, right, loc, id, hit)
 	send_value_if_needed(tI, gI)
 }
 
 func booleanGuidanceImpl(named_bools []NamedBool, message, id string, loc *locationInfo, guidanceFn guidanceFnType, hit bool) {
+	guidance_location_tracker.normalize(id, loc)
 	tI := boolean_guidance_tracker.getTrackerEntry(id)
+	// Each combination of named bools is only sent the first time it is seen
+	if hit && !tI.is_new(id, named_bools) {
+		return
+	}
 	bgI := build_boolean_guidance(guidanceFn, message, named_bools, loc, id, hit)
 	tI.send_value(bgI)
 }
 
-// NumericGuidanceRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+// Maximize tells Antithesis to favor explorations where value is larger, without asserting anything about it. Use it to steer testing towards interesting quantities, such as the depth of a queue or the number of retries. Only values larger than every value reported before with the same message are sent.
+func Maximize[T Number](value T, message string) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	var zero T
+	numericGuidanceImpl(value, zero, message, id, loc, guidanceFnMaximize, wasHit)
+}
+
+// Minimize tells Antithesis to favor explorations where value is smaller, without asserting anything about it. Only values smaller than every value reported before with the same message are sent.
+func Minimize[T Number](value T, message string) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	var zero T
+	numericGuidanceImpl(value, zero, message, id, loc, guidanceFnMinimize, wasHit)
+}
+
+// NumericGuidanceRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it. New frameworks should prefer EmitNumericGuidance.
 func NumericGuidanceRaw[T Number](
 	left, right T,
 	message, id string,
@@ -159,12 +201,10 @@ func NumericGuidanceRaw[T Number](
 	behavior string,
 	hit bool,
 ) {
-	loc := &locationInfo{classname, funcname, filename, line, columnUnknown}
-	guidanceFn := behavior_to_guidance(behavior)
-	numericGuidanceImpl(left, right, message, id, loc, guidanceFn, hit)
+	EmitNumericGuidance(rawGuidance(message, id, classname, funcname, filename, line, behavior, hit), left, right)
 }
 
-// BooleanGuidanceRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+// BooleanGuidanceRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it. New frameworks should prefer EmitBooleanGuidance.
 func BooleanGuidanceRaw(
 	named_bools []NamedBool,
 	message, id string,
@@ -173,9 +213,31 @@ func BooleanGuidanceRaw(
 	behavior string,
 	hit bool,
 ) {
-	loc := &locationInfo{classname, funcname, filename, line, columnUnknown}
-	guidanceFn := behavior_to_guidance(behavior)
-	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFn, hit)
+	EmitBooleanGuidance(rawGuidance(message, id, classname, funcname, filename, line, behavior, hit), named_bools)
+}
+
+func rawGuidance(message, id, classname, funcname, filename string, line int, behavior string, hit bool) RawGuidance {
+	return RawGuidance{
+		Location: Location{Classname: classname, Funcname: funcname, Filename: filename, Line: line},
+		Id:       id,
+		Message:  message,
+		Behavior: behavior,
+		Hit:      hit,
+	}
+}
+
+// EmitNumericGuidance is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+func EmitNumericGuidance[T Number](guidance RawGuidance, left, right T) {
+	loc := locationInfoFrom(guidance.Location)
+	guidanceFn := behavior_to_guidance(guidance.Behavior)
+	numericGuidanceImpl(left, right, guidance.Message, guidance.Id, loc, guidanceFn, guidance.Hit)
+}
+
+// EmitBooleanGuidance is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+func EmitBooleanGuidance(guidance RawGuidance, named_bools []NamedBool) {
+	loc := locationInfoFrom(guidance.Location)
+	guidanceFn := behavior_to_guidance(guidance.Behavior)
+	booleanGuidanceImpl(named_bools, guidance.Message, guidance.Id, loc, guidanceFn, guidance.Hit)
 }
 
 func add_numeric_details[T Number](details map[string]any, left, right T) map[string]any {
@@ -187,11 +249,19 @@ func add_numeric_details[T Number](details map[string]any, left, right T) map[st
 	for k, v := range details {
 		enhancedDetails[k] = v
 	}
-	enhancedDetails["left"] = left
-	enhancedDetails["right"] = right
+	enhancedDetails["left"] = detail_number(left)
+	enhancedDetails["right"] = detail_number(right)
 	return enhancedDetails
 }
 
+// detail_number renders durations as text such as "1.5s", rather than as a number of nanoseconds
+func detail_number[T Number](value T) any {
+	if duration, ok := any(value).(time.Duration); ok {
+		return duration.String()
+	}
+	return value
+}
+
 func add_boolean_details(details map[string]any, named_bools []NamedBool) map[string]any {
 	// ----------------------------------------------------
 	// Can not use maps.Clone() until go 1.21.0 or above
@@ -207,6 +277,28 @@ func add_boolean_details(details map[string]any, named_bools []NamedBool) map[st
 	return enhancedDetails
 }
 
+// lazy_numeric_details defers both the user details and the addition of left and right
+// until the tracker decides to emit
+func lazy_numeric_details[T Number](details func() map[string]any, left, right T) func() map[string]any {
+	return func() map[string]any {
+		var user_details map[string]any
+		if details != nil {
+			user_details = details()
+		}
+		return add_numeric_details(user_details, left, right)
+	}
+}
+
+func lazy_boolean_details(details func() map[string]any, named_bools []NamedBool) func() map[string]any {
+	return func() map[string]any {
+		var user_details map[string]any
+		if details != nil {
+			user_details = details()
+		}
+		return add_boolean_details(user_details, named_bools)
+	}
+}
+
 // Equivalent to asserting Always(left > right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func AlwaysGreaterThan[T Number](left, right T, message string, details map[string]any) {
 	loc := newLocationInfo(offsetAPICaller)
@@ -295,6 +387,50 @@ func SometimesLessThanOrEqualTo[T Number](left, right T, message string, details
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
 }
 
+// AlwaysInRange asserts that low <= value <= high every time this function is called, and that it is called at least once. Information about value, low and high will automatically be added to the details parameter, with keys value, low and high. Antithesis will try to bring value closer to whichever bound it is nearer to, and past it.
+func AlwaysInRange[T Number](value, low, high T, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := low <= value && value <= high
+	all_details := add_range_details(details, value, low, high)
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	rangeGuidanceImpl(value, low, high, message, id, loc, guidanceFnMinimize)
+}
+
+// SometimesInRange asserts that low <= value <= high at least one time that this function is called. Information about value, low and high will automatically be added to the details parameter, with keys value, low and high. Antithesis will try to bring value inside the range, starting from whichever bound it is nearer to.
+func SometimesInRange[T Number](value, low, high T, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := low <= value && value <= high
+	all_details := add_range_details(details, value, low, high)
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	rangeGuidanceImpl(value, low, high, message, id, loc, guidanceFnMaximize)
+}
+
+// rangeGuidanceImpl provides guidance on the distance from value to the nearer
+// bound, which is positive inside the range and negative outside it.
+// The distances are compared as float64 so that they can not overflow.
+func rangeGuidanceImpl[T Number](value, low, high T, message, id string, loc *locationInfo, guidanceFn guidanceFnType) {
+	if float64(value)-float64(low) <= float64(high)-float64(value) {
+		numericGuidanceImpl(value, low, message, id, loc, guidanceFn, wasHit)
+		return
+	}
+	numericGuidanceImpl(high, value, message, id, loc, guidanceFn, wasHit)
+}
+
+func add_range_details[T Number](details map[string]any, value, low, high T) map[string]any {
+	enhancedDetails := map[string]any{}
+	for k, v := range details {
+		enhancedDetails[k] = v
+	}
+	enhancedDetails["value"] = detail_number(value)
+	enhancedDetails["low"] = detail_number(low)
+	enhancedDetails["high"] = detail_number(high)
+	return enhancedDetails
+}
+
 // Asserts that every time this is called, at least one bool in named_bools is true. Equivalent to Always(named_bools[0].second || named_bools[1].second || ..., message, details). If you use this for assertions about the behavior of booleans, you may help Antithesis find more bugs. Information about named_bools will automatically be added to the details parameter, and the keys will be the names of the bools.
 func AlwaysSome(named_bools []NamedBool, message string, details map[string]any) {
 	loc := newLocationInfo(offsetAPICaller)
@@ -328,3 +464,205 @@ func SometimesAll(named_bools []NamedBool, message string, details map[string]an
 
 	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantAll, wasHit)
 }
+
+// Asserts that every time this is called, every bool in named_bools is true. Equivalent to Always(named_bools[0].second && named_bools[1].second && ..., message, details). If you use this for assertions about the behavior of booleans, you may help Antithesis find more bugs. Information about named_bools will automatically be added to the details parameter, and the keys will be the names of the bools.
+func AlwaysAll(named_bools []NamedBool, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := all_named_bools(named_bools)
+	all_details := add_boolean_details(details, named_bools)
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantNone, wasHit)
+}
+
+// Asserts that every time this is called, every bool in named_bools is false. Equivalent to Always(!named_bools[0].second && !named_bools[1].second && ..., message, details). If you use this for assertions about the behavior of booleans, you may help Antithesis find more bugs. Information about named_bools will automatically be added to the details parameter, and the keys will be the names of the bools.
+func AlwaysNone(named_bools []NamedBool, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := !some_named_bool(named_bools)
+	all_details := add_boolean_details(details, named_bools)
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantAll, wasHit)
+}
+
+// Asserts that at least one time this is called, at least one bool in named_bools is true. Equivalent to Sometimes(named_bools[0].second || named_bools[1].second || ..., message, details). If you use this for assertions about the behavior of booleans, you may help Antithesis find more bugs. Information about named_bools will automatically be added to the details parameter, and the keys will be the names of the bools.
+func SometimesSome(named_bools []NamedBool, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := some_named_bool(named_bools)
+	all_details := add_boolean_details(details, named_bools)
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantAll, wasHit)
+}
+
+// Asserts that at least one time this is called, every bool in named_bools is false. Equivalent to Sometimes(!named_bools[0].second && !named_bools[1].second && ..., message, details). If you use this for assertions about the behavior of booleans, you may help Antithesis find more bugs. Information about named_bools will automatically be added to the details parameter, and the keys will be the names of the bools.
+func SometimesNone(named_bools []NamedBool, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := !some_named_bool(named_bools)
+	all_details := add_boolean_details(details, named_bools)
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantNone, wasHit)
+}
+
+func some_named_bool(named_bools []NamedBool) bool {
+	for _, named_bool := range named_bools {
+		if named_bool.Second {
+			return true
+		}
+	}
+	return false
+}
+
+func all_named_bools(named_bools []NamedBool) bool {
+	for _, named_bool := range named_bools {
+		if !named_bool.Second {
+			return false
+		}
+	}
+	return true
+}
+
+// AlwaysGreaterThanLazy is equivalent to AlwaysGreaterThan, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysGreaterThanLazy[T Number](left, right T, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left > right
+	assertLazyImpl(condition, message, lazy_numeric_details(details, left, right), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
+}
+
+// AlwaysGreaterThanOrEqualToLazy is equivalent to AlwaysGreaterThanOrEqualTo, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysGreaterThanOrEqualToLazy[T Number](left, right T, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left >= right
+	assertLazyImpl(condition, message, lazy_numeric_details(details, left, right), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
+}
+
+// SometimesGreaterThanLazy is equivalent to SometimesGreaterThan, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func SometimesGreaterThanLazy[T Number](left, right T, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left > right
+	assertLazyImpl(condition, message, lazy_numeric_details(details, left, right), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
+}
+
+// SometimesGreaterThanOrEqualToLazy is equivalent to SometimesGreaterThanOrEqualTo, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func SometimesGreaterThanOrEqualToLazy[T Number](left, right T, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left >= right
+	assertLazyImpl(condition, message, lazy_numeric_details(details, left, right), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
+}
+
+// AlwaysLessThanLazy is equivalent to AlwaysLessThan, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysLessThanLazy[T Number](left, right T, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left < right
+	assertLazyImpl(condition, message, lazy_numeric_details(details, left, right), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
+}
+
+// AlwaysLessThanOrEqualToLazy is equivalent to AlwaysLessThanOrEqualTo, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysLessThanOrEqualToLazy[T Number](left, right T, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left <= right
+	assertLazyImpl(condition, message, lazy_numeric_details(details, left, right), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
+}
+
+// SometimesLessThanLazy is equivalent to SometimesLessThan, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func SometimesLessThanLazy[T Number](left, right T, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left < right
+	assertLazyImpl(condition, message, lazy_numeric_details(details, left, right), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
+}
+
+// SometimesLessThanOrEqualToLazy is equivalent to SometimesLessThanOrEqualTo, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func SometimesLessThanOrEqualToLazy[T Number](left, right T, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left <= right
+	assertLazyImpl(condition, message, lazy_numeric_details(details, left, right), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
+}
+
+// AlwaysSomeLazy is equivalent to AlwaysSome, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysSomeLazy(named_bools []NamedBool, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := some_named_bool(named_bools)
+	assertLazyImpl(condition, message, lazy_boolean_details(details, named_bools), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantNone, wasHit)
+}
+
+// SometimesAllLazy is equivalent to SometimesAll, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func SometimesAllLazy(named_bools []NamedBool, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := all_named_bools(named_bools)
+	assertLazyImpl(condition, message, lazy_boolean_details(details, named_bools), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantAll, wasHit)
+}
+
+// AlwaysAllLazy is equivalent to AlwaysAll, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysAllLazy(named_bools []NamedBool, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := all_named_bools(named_bools)
+	assertLazyImpl(condition, message, lazy_boolean_details(details, named_bools), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantNone, wasHit)
+}
+
+// AlwaysNoneLazy is equivalent to AlwaysNone, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func AlwaysNoneLazy(named_bools []NamedBool, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := !some_named_bool(named_bools)
+	assertLazyImpl(condition, message, lazy_boolean_details(details, named_bools), loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantAll, wasHit)
+}
+
+// SometimesSomeLazy is equivalent to SometimesSome, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func SometimesSomeLazy(named_bools []NamedBool, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := some_named_bool(named_bools)
+	assertLazyImpl(condition, message, lazy_boolean_details(details, named_bools), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantAll, wasHit)
+}
+
+// SometimesNoneLazy is equivalent to SometimesNone, except that details is a function which is only called when the evaluation of the assertion is actually reported. Use it when details are expensive to compute.
+func SometimesNoneLazy(named_bools []NamedBool, message string, details func() map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := !some_named_bool(named_bools)
+	assertLazyImpl(condition, message, lazy_boolean_details(details, named_bools), loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantNone, wasHit)
+}
@@ -11,9 +11,45 @@ func AlwaysLessThanOrEqualTo[T Number](left, right T, message string, details ma
 func SometimesLessThan[T Number](left, right T, message string, details map[string]any)             {}
 func SometimesLessThanOrEqualTo[T Number](left, right T, message string, details map[string]any)    {}
 
+func AlwaysInRange[T Number](value, low, high T, message string, details map[string]any)    {}
+func SometimesInRange[T Number](value, low, high T, message string, details map[string]any) {}
+
 func AlwaysSome(named_bool []NamedBool, message string, details map[string]any)   {}
 func SometimesAll(named_bool []NamedBool, message string, details map[string]any) {}
 
+func AlwaysAll(named_bool []NamedBool, message string, details map[string]any)     {}
+func AlwaysNone(named_bool []NamedBool, message string, details map[string]any)    {}
+func SometimesSome(named_bool []NamedBool, message string, details map[string]any) {}
+func SometimesNone(named_bool []NamedBool, message string, details map[string]any) {}
+
+func AlwaysGreaterThanLazy[T Number](left, right T, message string, details func() map[string]any) {
+}
+func AlwaysGreaterThanOrEqualToLazy[T Number](left, right T, message string, details func() map[string]any) {
+}
+func SometimesGreaterThanLazy[T Number](left, right T, message string, details func() map[string]any) {
+}
+func SometimesGreaterThanOrEqualToLazy[T Number](left, right T, message string, details func() map[string]any) {
+}
+func AlwaysLessThanLazy[T Number](left, right T, message string, details func() map[string]any) {
+}
+func AlwaysLessThanOrEqualToLazy[T Number](left, right T, message string, details func() map[string]any) {
+}
+func SometimesLessThanLazy[T Number](left, right T, message string, details func() map[string]any) {
+}
+func SometimesLessThanOrEqualToLazy[T Number](left, right T, message string, details func() map[string]any) {
+}
+
+func AlwaysSomeLazy(named_bool []NamedBool, message string, details func() map[string]any)   {}
+func SometimesAllLazy(named_bool []NamedBool, message string, details func() map[string]any) {}
+
+func AlwaysAllLazy(named_bool []NamedBool, message string, details func() map[string]any)     {}
+func AlwaysNoneLazy(named_bool []NamedBool, message string, details func() map[string]any)    {}
+func SometimesSomeLazy(named_bool []NamedBool, message string, details func() map[string]any) {}
+func SometimesNoneLazy(named_bool []NamedBool, message string, details func() map[string]any) {}
+
+func Maximize[T Number](value T, message string) {}
+func Minimize[T Number](value T, message string) {}
+
 func NumericGuidanceRaw[T Number](left, right T,
 	message, id string,
 	classname, funcname, filename string,
@@ -32,3 +68,6 @@ func BooleanGuidanceRaw(
 	hit bool,
 ) {
 }
+
+func EmitNumericGuidance[T Number](guidance RawGuidance, left, right T) {}
+func EmitBooleanGuidance(guidance RawGuidance, named_bools []NamedBool) {}
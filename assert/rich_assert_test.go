@@ -0,0 +1,148 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRangeGuidance(t *testing.T) {
+	output := captureOutput(t)
+
+	// The guidance follows the nearer bound, and is only sent
+	// when value gets closer to leaving the range
+	for _, value := range []int64{50, 20, 85, 30, 101} {
+		AlwaysInRange(value, 0, 100, "value in range", nil)
+	}
+	expected := []numericOperands[int64]{{50, 0}, {20, 0}, {100, 85}, {100, 101}}
+	operands := output.numericData()
+	if len(operands) != len(expected) {
+		t.Fatalf("expected guidance %v, got %v", expected, operands)
+	}
+	for i := range expected {
+		if operands[i] != expected[i] {
+			t.Fatalf("expected guidance %v, got %v", expected, operands)
+		}
+	}
+}
+
+func TestBooleanAssertions(t *testing.T) {
+	output := captureOutput(t)
+
+	mixed := []NamedBool{{"a", true}, {"b", false}}
+	none := []NamedBool{{"a", false}, {"b", false}}
+	AlwaysAll(mixed, "always all", nil)
+	AlwaysNone(none, "always none", nil)
+	SometimesSome(mixed, "sometimes some", nil)
+	SometimesNone(mixed, "sometimes none", nil)
+
+	expected := map[string][2]bool{
+		"always all":     {false, false},
+		"always none":    {true, true},
+		"sometimes some": {true, true},
+		"sometimes none": {false, false},
+	}
+	conditions := map[string]bool{}
+	for _, assertion := range output.assertions() {
+		conditions[assertion.Message] = assertion.Condition
+	}
+	maximize := map[string]bool{}
+	for _, g := range output.guidance() {
+		maximize[g.Message] = g.Maximize
+	}
+	for message, want := range expected {
+		if conditions[message] != want[0] || maximize[message] != want[1] {
+			t.Fatalf("%s: expected condition %v and maximize %v, got %v and %v", message, want[0], want[1], conditions[message], maximize[message])
+		}
+	}
+}
+
+func TestMaximize(t *testing.T) {
+	output := captureOutput(t)
+
+	for _, depth := range []int{3, 1, 7, 7, 5, 9} {
+		Maximize(depth, "queue depth")
+	}
+	for _, g := range output.guidance() {
+		if !g.Maximize {
+			t.Fatalf("expected maximizing guidance: %+v", g)
+		}
+	}
+	expected := []numericOperands[int64]{{3, 0}, {7, 0}, {9, 0}}
+	sent := output.numericData()
+	if len(sent) != len(expected) || sent[0] != expected[0] || sent[1] != expected[1] || sent[2] != expected[2] {
+		t.Fatalf("expected only improvements to be sent, got %v", sent)
+	}
+}
+
+func TestEmitRaw(t *testing.T) {
+	output := captureOutput(t)
+
+	loc := Location{Classname: "example.com/server", Funcname: "Serve", Receiver: "*Server", Filename: "server.go", Line: 12, Column: 7}
+	EmitAssertion(RawAssertion{
+		Location:    loc,
+		Id:          "raw assertion",
+		Message:     "raw assertion",
+		AssertType:  "always",
+		DisplayType: "Always",
+		Condition:   true,
+		Hit:         true,
+	})
+	EmitNumericGuidance(RawGuidance{Location: loc, Id: "raw guidance", Message: "raw guidance", Behavior: "maximize", Hit: true}, 3, 1)
+	EmitBooleanGuidance(RawGuidance{Location: loc, Id: "raw flags", Message: "raw flags", Behavior: "all", Hit: true}, []NamedBool{{"ready", true}})
+	EmitJSONGuidance(RawGuidance{Location: loc, Id: "raw state", Message: "raw state", Hit: true}, map[string]int{"members": 3})
+	EmitParetoGuidance(RawGuidance{Location: loc, Id: "raw point", Message: "raw point", Hit: true}, []Dimension{{Name: "load", Value: 0.5, Maximize: true}})
+
+	sent := append(output.records(assertPrefix), output.records(guidancePrefix)...)
+	wantLocation := `"location":{"class":"example.com/server","function":"Serve","receiver":"*Server","file":"server.go","begin_line":12,"begin_column":7}`
+	if len(sent) != 5 {
+		t.Fatalf("expected an assertion and four guidance records, got %q", sent)
+	}
+	for _, message := range sent {
+		if !strings.Contains(message, wantLocation) {
+			t.Fatalf("expected the receiver and column in the location, got %s", message)
+		}
+	}
+
+	// The positional functions report the same records, without a receiver or column
+	output.clear()
+	AssertRaw(false, "positional assertion", nil, "example.com/server", "Serve", "server.go", 12, true, false, "always", "Always", "positional assertion")
+	NumericGuidanceRaw(3, 1, "positional guidance", "positional guidance", "example.com/server", "Serve", "server.go", 12, "minimize", true)
+	sent = append(output.records(assertPrefix), output.records(guidancePrefix)...)
+	wantLocation = `"location":{"class":"example.com/server","function":"Serve","file":"server.go","begin_line":12,"begin_column":0}`
+	if len(sent) != 2 || !strings.Contains(sent[0], wantLocation) || !strings.Contains(sent[0], `"condition":false`) || !strings.Contains(sent[1], `"maximize":false`) {
+		t.Fatalf("unexpected records %q", sent)
+	}
+}
+
+// idOfNextLine is the id of the property or guidance with message at the
+// line after the call to idOfNextLine, as the catalog would register it
+func idOfNextLine(message string) string {
+	loc := newLocationInfo(offsetAPICaller)
+	loc.Line++
+	return makeKey(message, loc)
+}
+
+func TestGuidanceLocations(t *testing.T) {
+	output := captureOutput(t)
+
+	// Standalone guidance is reported at the file and class registered by
+	// the catalog, rather than at the absolute path of this file
+	NumericGuidanceRaw(0, 0, "queue depth", idOfNextLine("queue depth"), "example.com/queue", "Push", "queue.go", 3, "maximize", false)
+	Maximize(5, "queue depth")
+	JSONGuidanceRaw(nil, "queue state", idOfNextLine("queue state"), "example.com/queue", "Push", "queue.go", 4, false)
+	Explore("queue state", "draining")
+	ParetoGuidanceRaw(nil, "queue load", idOfNextLine("queue load"), "example.com/queue", "Push", "queue.go", 5, false)
+	Pareto("queue load", []Dimension{{Name: "depth", Value: 5, Maximize: true}})
+
+	sent := output.records(guidancePrefix)
+	if len(sent) != 6 {
+		t.Fatalf("expected a registration and an evaluation of each guidance, got %q", sent)
+	}
+	for _, guidance := range sent {
+		if !strings.Contains(guidance, `"class":"example.com/queue"`) || !strings.Contains(guidance, `"file":"queue.go"`) {
+			t.Fatalf("expected the registered location, got %s", guidance)
+		}
+	}
+}
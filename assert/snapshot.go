@@ -0,0 +1,45 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"sort"
+)
+
+// Snapshot returns the status of every property known to this process, ordered by id. This includes properties registered in the assertion catalog which have not been evaluated yet, as well as the number of times each property has passed and failed so far.
+//
+// Snapshot reflects only the assertions evaluated within the current process. It is intended for reporting progress, for example from long-running tests, and does not affect what is sent to Antithesis.
+func Snapshot() []PropertyStatus {
+	statuses := []PropertyStatus{}
+	assertTracker.entries.Range(func(id, entry any) bool {
+		statuses = append(statuses, entry.(*trackerInfo).status(id.(string)))
+		return true
+	})
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Id < statuses[j].Id
+	})
+	return statuses
+}
+
+func (ti *trackerInfo) status(id string) PropertyStatus {
+	passCount, failCount := ti.PassCount.Load(), ti.FailCount.Load()
+	return PropertyStatus{
+		Location: Location{
+			Classname: ti.Location.Classname,
+			Funcname:  ti.Location.Funcname,
+			Receiver:  ti.Location.Receiver,
+			Filename:  ti.Location.Filename,
+			Line:      ti.Location.Line,
+			Column:    ti.Location.Column,
+		},
+		Id:          id,
+		Message:     ti.Message,
+		AssertType:  ti.AssertType,
+		DisplayType: ti.DisplayType,
+		PassCount:   int(passCount),
+		FailCount:   int(failCount),
+		MustHit:     ti.MustHit,
+		Hit:         passCount+failCount > 0,
+	}
+}
@@ -0,0 +1,43 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+)
+
+func findStatus(statuses []PropertyStatus, message string) *PropertyStatus {
+	for i := range statuses {
+		if statuses[i].Message == message {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func TestSnapshot(t *testing.T) {
+	resetTrackers()
+	defer resetTrackers()
+
+	AssertRaw(false, "registered only", nil, "class", "func", "file.go", 10, false, true, "sometimes", "Sometimes", "registered only")
+	for i := 0; i < 3; i++ {
+		AlwaysOrUnreachable(i < 2, "counted", nil)
+	}
+
+	statuses := Snapshot()
+	registered := findStatus(statuses, "registered only")
+	if registered == nil || registered.Hit || !registered.MustHit || registered.Location.Line != 10 {
+		t.Fatalf("unexpected status for registration: %+v", registered)
+	}
+
+	counted := findStatus(statuses, "counted")
+	if counted == nil {
+		t.Fatalf("no status for evaluated assertion")
+	}
+	if !counted.Hit || counted.PassCount != 2 || counted.FailCount != 1 {
+		t.Fatalf("unexpected counts: %+v", counted)
+	}
+	if counted.DisplayType != "AlwaysOrUnreachable" || counted.AssertType != "always" {
+		t.Fatalf("unexpected types: %+v", counted)
+	}
+}
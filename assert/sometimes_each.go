@@ -0,0 +1,156 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxBucketsPerProperty bounds the number of distinct buckets tracked for
+// each SometimesEach property. Further buckets share a single overflow bucket.
+const maxBucketsPerProperty = 64
+
+const overflowBucketText = "{overflow}"
+
+// bucketInfo identifies the property of a SometimesEach bucket
+// with the property it was derived from
+type bucketInfo struct {
+	Values      map[string]any `json:"values,omitempty"`
+	BaseMessage string         `json:"base_message"`
+	BaseId      string         `json:"base_id"`
+	Overflow    bool           `json:"overflow,omitempty"`
+}
+
+// propertyBuckets holds the bucket texts seen so far for a SometimesEach
+// property. The number of buckets is reserved before a bucket is stored,
+// so that the limit holds without a lock.
+type propertyBuckets struct {
+	texts      sync.Map
+	count      atomic.Int32
+	overflowed atomic.Bool
+}
+
+// bucketTracker maps each SometimesEach property id to its *propertyBuckets
+var bucketTracker sync.Map
+
+// SometimesEach asserts that, for every distinct value of bucket that this function is called with, it is called at least once. Each distinct bucket becomes a Sometimes property of its own, named after message and the contents of bucket, so that the triage report shows which combinations were exercised, for example one per RPC type and node role. The property named message itself is a Sometimes property which passes once this function is called with any bucket.
+//
+// Buckets are compared by the text of their values, as formatted with the %v verb. Values which cannot be sent as JSON, such as channels or NaN, are reported as that text. At most 64 distinct buckets are tracked for each message; any further buckets are reported together as a single overflow bucket, and a diagnostic event named "antithesis_sdk_diagnostic" is emitted. Only the property named message can be registered by the antithesis-go-generator, since buckets are only known at runtime.
+func SometimesEach(message string, bucket map[string]any, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	assertImpl(true, message, details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	values := bucketValues(bucket)
+	text := bucketText(values)
+	info := &bucketInfo{
+		Values:      values,
+		BaseMessage: message,
+		BaseId:      id,
+	}
+	if !trackBucket(id, text) {
+		text = overflowBucketText
+		info.Values = nil
+		info.Overflow = true
+	}
+
+	bucketLoc := *loc
+	aI := &assertInfo{
+		Hit:         wasHit,
+		MustHit:     mustBeHit,
+		AssertType:  existentialTest,
+		DisplayType: sometimesDisplay,
+		Message:     message + " " + text,
+		Condition:   true,
+		Id:          id + " " + text,
+		Location:    &bucketLoc,
+		Details:     details,
+		Bucket:      info,
+	}
+	trackAssertInfo(aI)
+}
+
+// bucketValues replaces the values of a bucket which cannot be sent as
+// JSON, such as channels or NaN, with their text
+func bucketValues(bucket map[string]any) map[string]any {
+	values := make(map[string]any, len(bucket))
+	for k, v := range bucket {
+		switch v.(type) {
+		case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		default:
+			if _, err := json.Marshal(v); err != nil {
+				v = fmt.Sprint(v)
+			}
+		}
+		values[k] = v
+	}
+	return values
+}
+
+// bucketText formats a bucket as "{key=value, ...}" with its keys in sorted order
+func bucketText(bucket map[string]any) string {
+	keys := make([]string, 0, len(bucket))
+	for k := range bucket {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s=%v", k, bucket[k])
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// trackBucket records a bucket of the property with the given id, and returns
+// false if the bucket is new and the property already has too many buckets
+func trackBucket(id, text string) bool {
+	entry, ok := bucketTracker.Load(id)
+	if !ok {
+		entry, _ = bucketTracker.LoadOrStore(id, &propertyBuckets{})
+	}
+	buckets := entry.(*propertyBuckets)
+	if _, ok := buckets.texts.Load(text); ok {
+		return true
+	}
+
+	for {
+		count := buckets.count.Load()
+		if count >= maxBucketsPerProperty {
+			break
+		}
+		if buckets.count.CompareAndSwap(count, count+1) {
+			if _, loaded := buckets.texts.LoadOrStore(text, true); loaded {
+				buckets.count.Add(-1)
+			}
+			return true
+		}
+	}
+
+	// The bucket may have been stored while the limit was reached
+	if _, ok := buckets.texts.Load(text); ok {
+		return true
+	}
+	if buckets.overflowed.CompareAndSwap(false, true) {
+		emitDiagnostic(map[string]any{
+			"kind":        "bucket_limit_exceeded",
+			"id":          id,
+			"max_buckets": maxBucketsPerProperty,
+		})
+	}
+	return false
+}
+
+func resetBuckets() {
+	clearSyncMap(&bucketTracker)
+}
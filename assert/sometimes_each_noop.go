@@ -0,0 +1,5 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+func SometimesEach(message string, bucket map[string]any, details map[string]any) {}
@@ -0,0 +1,66 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func assertionIds(output *capturedOutput) []string {
+	ids := []string{}
+	for _, assertion := range output.assertions() {
+		ids = append(ids, assertion.Id)
+	}
+	return ids
+}
+
+func TestSometimesEach(t *testing.T) {
+	output := captureOutput(t)
+
+	buckets := []map[string]any{
+		{"rpc": "Get", "role": "leader"},
+		{"role": "leader", "rpc": "Get"},
+		{"rpc": "Put", "role": "leader"},
+	}
+	for _, bucket := range buckets {
+		SometimesEach("rpc served", bucket, nil)
+	}
+	ids := assertionIds(output)
+	// Bucket ids extend the id of the property, which includes its location
+	// when built with location ids
+	if len(ids) != 3 || !strings.HasPrefix(ids[0], "rpc served") || ids[1] != ids[0]+" {role=leader, rpc=Get}" || ids[2] != ids[0]+" {role=leader, rpc=Put}" {
+		t.Fatalf("expected the property followed by one id for each bucket, got %q", ids)
+	}
+
+	output.clear()
+	for i := 0; i < maxBucketsPerProperty+10; i++ {
+		SometimesEach("request size", map[string]any{"size": i}, nil)
+	}
+	ids = assertionIds(output)
+	if len(ids) != maxBucketsPerProperty+2 || ids[len(ids)-1] != ids[0]+" {overflow}" {
+		t.Fatalf("expected %d buckets and an overflow bucket, got %d: %v", maxBucketsPerProperty, len(ids)-1, ids[len(ids)-1])
+	}
+	if diagnostics := output.count(diagnosticPrefix); diagnostics != 1 {
+		t.Fatalf("expected one diagnostic, got %d", diagnostics)
+	}
+	if text := bucketText(nil); text != "{}" {
+		t.Fatalf("unexpected text for an empty bucket: %s", text)
+	}
+	if text := bucketText(map[string]any{"code": fmt.Errorf("EIO")}); text != "{code=EIO}" {
+		t.Fatalf("unexpected bucket text: %s", text)
+	}
+
+	// Values which cannot be sent as JSON are sent as their text, so that
+	// their bucket is emitted once rather than retried on every call
+	output.clear()
+	for i := 0; i < 3; i++ {
+		SometimesEach("ratio seen", map[string]any{"ratio": math.NaN()}, nil)
+	}
+	ids = assertionIds(output)
+	if len(ids) != 2 || ids[1] != ids[0]+" {ratio=NaN}" {
+		t.Fatalf("expected the property and its bucket to be emitted once, got %q", ids)
+	}
+}
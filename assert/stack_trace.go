@@ -0,0 +1,47 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StackTraceMode decides whether a stack trace is added to the details of the first failing evaluation of an Always, AlwaysOrUnreachable or Unreachable property.
+type StackTraceMode int
+
+const (
+	StackTraceNone      StackTraceMode = iota // No stack trace is captured. This is the default mode.
+	StackTraceGoroutine                       // The stack of the goroutine evaluating the assertion is captured
+	StackTraceAll                             // The stacks of all goroutines are captured
+)
+
+// StackTraceKey is the details key under which a captured stack trace is reported. It replaces any value provided with that key.
+const StackTraceKey = "antithesis_stack_trace"
+
+// Captured stack traces are truncated to these sizes, in bytes
+const (
+	maxGoroutineStackTraceBytes = 64 << 10
+	maxAllStackTracesBytes      = 1 << 20
+)
+
+func (m StackTraceMode) String() string {
+	switch m {
+	case StackTraceGoroutine:
+		return "goroutine"
+	case StackTraceAll:
+		return "all"
+	}
+	return "none"
+}
+
+// parseStackTraceMode accepts the text produced by StackTraceMode.String()
+func parseStackTraceMode(text string) (StackTraceMode, error) {
+	switch strings.TrimSpace(text) {
+	case "none", "":
+		return StackTraceNone, nil
+	case "goroutine":
+		return StackTraceGoroutine, nil
+	case "all":
+		return StackTraceAll, nil
+	}
+	return StackTraceNone, fmt.Errorf("invalid stack trace mode %q: expected one of none, goroutine or all", text)
+}
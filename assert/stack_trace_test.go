@@ -0,0 +1,42 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStackTrace(t *testing.T) {
+	output := captureOutput(t)
+	defer SetStackTraceMode(SetStackTraceMode(StackTraceGoroutine))
+	defer SetEmitPolicy(SetEmitPolicy(EmitAll()))
+
+	details := map[string]any{"attempt": 1}
+	for _, condition := range []bool{true, false, false} {
+		Always(condition, "stack trace", details)
+	}
+	Sometimes(false, "no stack trace", nil)
+
+	var traces []string
+	for _, assertion := range output.assertions() {
+		trace, _ := assertion.Details[StackTraceKey].(string)
+		traces = append(traces, trace)
+	}
+	if len(traces) != 4 || traces[0] != "" || traces[2] != "" || traces[3] != "" {
+		t.Fatalf("expected a stack trace for the first failure only, got %q", traces)
+	}
+	if !strings.Contains(traces[1], "TestStackTrace") {
+		t.Fatalf("expected the stack trace to include the failing caller, got %q", traces[1])
+	}
+	if _, ok := details[StackTraceKey]; ok {
+		t.Fatalf("the details provided should not be modified")
+	}
+
+	if mode, err := parseStackTraceMode("all"); err != nil || mode != StackTraceAll {
+		t.Fatalf("unexpected mode %v: %v", mode, err)
+	}
+	if _, err := parseStackTraceMode("some"); err == nil {
+		t.Fatalf("expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,145 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal/callsite"
+)
+
+// Bounds on the state kept by a TB. Only the latest maxTBLogLines lines
+// logged with Logf are kept, and at most maxTBHelperDepth calls are
+// skipped to find the caller of Errorf outside of helper functions.
+const (
+	maxTBLogLines    = 100
+	maxTBHelperDepth = 50
+)
+
+// TB reports failures of validation code written against testing.TB as Antithesis properties. It implements the Errorf, Fatalf, Helper, Logf and Cleanup methods of testing.TB, so that code which only uses those methods can take a *TB in place of a *testing.T, through an interface of your own. Create one with T.
+//
+// A TB is safe for use by multiple goroutines.
+type TB struct {
+	prefix   string
+	mutex    sync.Mutex
+	helpers  map[string]bool
+	logs     []string
+	cleanups []func()
+	failed   bool
+}
+
+// T returns a TB whose properties have messages starting with msgPrefix.
+func T(msgPrefix string) *TB {
+	return &TB{prefix: msgPrefix, helpers: map[string]bool{}}
+}
+
+// Errorf reports a failure at the place it is called, as a failing Always property whose message is the message prefix of t followed by format. Places which call Errorf with the same format share a property, unless the program is built with the antithesis_location_ids tag, which makes each place a distinct property. The formatted text is added to the details with the key "error", along with the lines logged so far with the key "logs".
+//
+// Functions which have called Helper are skipped when finding the place Errorf is called, as they are by testing.T.
+func (t *TB) Errorf(format string, args ...any) {
+	t.report(format, args)
+}
+
+// Fatalf is equivalent to Errorf followed by runtime.Goexit, which stops the calling goroutine as testing.T.FailNow does. Deferred calls, such as a deferred call to Finish, still run.
+func (t *TB) Fatalf(format string, args ...any) {
+	t.report(format, args)
+	runtime.Goexit()
+}
+
+// Helper marks the calling function as a helper function, which is skipped when finding the place Errorf or Fatalf is called.
+func (t *TB) Helper() {
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.helpers[frame.Function] = true
+}
+
+// Logf records a line of text, which is added to the details of the failures reported afterwards.
+func (t *TB) Logf(format string, args ...any) {
+	line := fmt.Sprintf(format, args...)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.logs) == maxTBLogLines {
+		t.logs = append(t.logs[:0], t.logs[1:]...)
+	}
+	t.logs = append(t.logs, line)
+}
+
+// Cleanup registers f to be called by Finish. Functions are called in the reverse order to the order they were registered in.
+func (t *TB) Cleanup(f func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.cleanups = append(t.cleanups, f)
+}
+
+// Finish calls the functions registered with Cleanup, most recently registered first. Unlike testing.T, a TB does not know when the code using it is done, so Finish should be called, or deferred, once it is.
+func (t *TB) Finish() {
+	for {
+		t.mutex.Lock()
+		n := len(t.cleanups)
+		if n == 0 {
+			t.mutex.Unlock()
+			return
+		}
+		f := t.cleanups[n-1]
+		t.cleanups = t.cleanups[:n-1]
+		t.mutex.Unlock()
+		f()
+	}
+}
+
+// Failed reports whether Errorf or Fatalf has been called.
+func (t *TB) Failed() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.failed
+}
+
+func (t *TB) report(format string, args []any) {
+	message := format
+	if t.prefix != "" {
+		message = t.prefix + ": " + format
+	}
+
+	t.mutex.Lock()
+	t.failed = true
+	details := map[string]any{
+		"error": fmt.Sprintf(format, args...),
+	}
+	if len(t.logs) > 0 {
+		details["logs"] = append([]string{}, t.logs...)
+	}
+	loc := t.callerLocation()
+	t.mutex.Unlock()
+
+	assertImpl(false, message, details, loc, wasHit, optionallyHit, universalTest, alwaysDisplay, makeKey(message, loc))
+}
+
+// callerLocation must be called with t.mutex held. It finds the caller of
+// Errorf or Fatalf which is not a helper function, or the direct caller if
+// every caller is a helper function.
+func (t *TB) callerLocation() *locationInfo {
+	// Skip runtime.Callers, callerLocation, report and Errorf or Fatalf
+	pcs := make([]uintptr, maxTBHelperDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(4, pcs)])
+	caller, more := frames.Next()
+	frame := caller
+	for t.helpers[frame.Function] && more {
+		frame, more = frames.Next()
+	}
+	if t.helpers[frame.Function] {
+		return frameLocationInfo(caller)
+	}
+	return frameLocationInfo(frame)
+}
+
+func frameLocationInfo(frame runtime.Frame) *locationInfo {
+	classname, funcname := callsite.SplitFuncName(frame.Function)
+	return &locationInfo{Classname: classname, Funcname: funcname, Filename: frame.File, Line: frame.Line, Column: columnUnknown}
+}
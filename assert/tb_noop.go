@@ -0,0 +1,59 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Without the SDK, a TB reports nothing, but Fatalf still stops the
+// calling goroutine and Finish still calls the cleanup functions
+type TB struct {
+	mutex    sync.Mutex
+	cleanups []func()
+	failed   bool
+}
+
+func T(msgPrefix string) *TB { return &TB{} }
+
+func (t *TB) Errorf(format string, args ...any) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.failed = true
+}
+
+func (t *TB) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func (t *TB) Helper()                         {}
+func (t *TB) Logf(format string, args ...any) {}
+
+func (t *TB) Cleanup(f func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *TB) Finish() {
+	for {
+		t.mutex.Lock()
+		n := len(t.cleanups)
+		if n == 0 {
+			t.mutex.Unlock()
+			return
+		}
+		f := t.cleanups[n-1]
+		t.cleanups = t.cleanups[:n-1]
+		t.mutex.Unlock()
+		f()
+	}
+}
+
+func (t *TB) Failed() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.failed
+}
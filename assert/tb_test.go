@@ -0,0 +1,71 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import "testing"
+
+// validationTB is the subset of testing.TB used by validation code,
+// which both *testing.T and *TB implement
+type validationTB interface {
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+	Helper()
+	Logf(format string, args ...any)
+	Cleanup(f func())
+}
+
+var (
+	_ validationTB = (*testing.T)(nil)
+	_ validationTB = (*TB)(nil)
+)
+
+func checkPositive(t validationTB, value int) {
+	t.Helper()
+	if value <= 0 {
+		t.Errorf("value %d is not positive", value)
+	}
+}
+
+func TestTB(t *testing.T) {
+	output := captureOutput(t)
+	defer SetEmitPolicy(SetEmitPolicy(EmitAll()))
+
+	tb := T("validation")
+	tb.Logf("checking %d values", 2)
+	checkPositive(tb, 1)
+	checkPositive(tb, -1) // first call site
+	checkPositive(tb, -2) // second call site
+
+	reported := output.assertions()
+	if !tb.Failed() || len(reported) != 2 {
+		t.Fatalf("expected one failure for each call site, got %d", len(reported))
+	}
+	first, second := reported[0], reported[1]
+	// Call sites are told apart like other assertions, by their location
+	// only when built with location ids
+	if first.Message != "validation: value %d is not positive" || first.Message != second.Message || first.Id != makeKey(first.Message, first.Location) || (first.Id == second.Id) == useLocationIds {
+		t.Fatalf("unexpected messages and ids %q %q and %q %q", first.Message, first.Id, second.Message, second.Id)
+	}
+	if first.Location.Funcname != "TestTB" || second.Location.Line != first.Location.Line+1 {
+		t.Fatalf("expected the helper to be skipped, got %+v and %+v", first.Location, second.Location)
+	}
+	if first.Details["error"] != "value -1 is not positive" || len(first.Details["logs"].([]any)) != 1 {
+		t.Fatalf("unexpected details %v", first.Details)
+	}
+
+	// Fatalf stops the goroutine, after which the cleanups still run
+	var order []int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer tb.Finish()
+		tb.Cleanup(func() { order = append(order, 1) })
+		tb.Cleanup(func() { order = append(order, 2) })
+		tb.Fatalf("giving up")
+		order = append(order, 0)
+	}()
+	<-done
+	if len(order) != 2 || order[0] != 2 || order[1] != 1 || output.count(assertPrefix) != 3 {
+		t.Fatalf("expected Fatalf to stop the goroutine and the cleanups to run in reverse order, got %v", order)
+	}
+}
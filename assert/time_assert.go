@@ -0,0 +1,65 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"math"
+	"time"
+)
+
+// AlwaysBefore asserts that left is before right every time this function is called, and that it is called at least once. Information about left and right will automatically be added to the details parameter, with keys left and right, along with the difference right - left with key difference. Antithesis will try to bring left closer to, and past, right.
+func AlwaysBefore(left, right time.Time, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left.Before(right)
+	all_details := add_time_details(details, left, right)
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	numericGuidanceImpl(left.Sub(right), 0, message, id, loc, guidanceFnMaximize, wasHit)
+}
+
+// AlwaysAfter asserts that left is after right every time this function is called, and that it is called at least once. Information about left and right will automatically be added to the details parameter, with keys left and right, along with the difference right - left with key difference. Antithesis will try to bring left closer to, and past, right.
+func AlwaysAfter(left, right time.Time, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	condition := left.After(right)
+	all_details := add_time_details(details, left, right)
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	numericGuidanceImpl(left.Sub(right), 0, message, id, loc, guidanceFnMinimize, wasHit)
+}
+
+// SometimesWithin asserts that left and right are at most maxSkew apart, in either order, at least one time that this function is called. Information about left, right and maxSkew will automatically be added to the details parameter, with keys left, right and max_skew, along with the difference right - left with key difference. Antithesis will try to bring left and right closer together.
+func SometimesWithin(left, right time.Time, maxSkew time.Duration, message string, details map[string]any) {
+	loc := newLocationInfo(offsetAPICaller)
+	id := makeKey(message, loc)
+	skew := abs_duration(left.Sub(right))
+	condition := skew <= maxSkew
+	all_details := add_time_details(details, left, right)
+	all_details["max_skew"] = maxSkew.String()
+	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+
+	numericGuidanceImpl(skew, maxSkew, message, id, loc, guidanceFnMinimize, wasHit)
+}
+
+// abs_duration saturates, as time.Time.Sub does, rather than overflowing
+func abs_duration(d time.Duration) time.Duration {
+	if d >= 0 {
+		return d
+	}
+	if d == math.MinInt64 {
+		return math.MaxInt64
+	}
+	return -d
+}
+
+func add_time_details(details map[string]any, left, right time.Time) map[string]any {
+	enhancedDetails := map[string]any{}
+	for k, v := range details {
+		enhancedDetails[k] = v
+	}
+	enhancedDetails["left"] = left.Format(time.RFC3339Nano)
+	enhancedDetails["right"] = right.Format(time.RFC3339Nano)
+	enhancedDetails["difference"] = right.Sub(left).String()
+	return enhancedDetails
+}
@@ -0,0 +1,10 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+import "time"
+
+func AlwaysBefore(left, right time.Time, message string, details map[string]any) {}
+func AlwaysAfter(left, right time.Time, message string, details map[string]any)  {}
+func SometimesWithin(left, right time.Time, maxSkew time.Duration, message string, details map[string]any) {
+}
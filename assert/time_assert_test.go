@@ -0,0 +1,44 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeAssertions(t *testing.T) {
+	output := captureOutput(t)
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	later := start.Add(1500 * time.Millisecond)
+	AlwaysBefore(start, later, "before", nil)
+	AlwaysAfter(start, later, "after", nil)
+	SometimesWithin(later, start, time.Second, "within", nil)
+	AlwaysLessThan(1500*time.Millisecond, 2*time.Second, "lease", nil)
+
+	asserts := map[string]*assertInfo{}
+	for _, assertion := range output.assertions() {
+		asserts[assertion.Message] = assertion
+	}
+	guidance := map[string]*guidanceInfo{}
+	for _, g := range output.guidance() {
+		guidance[g.Message] = g
+	}
+	if !asserts["before"].Condition || asserts["after"].Condition || asserts["within"].Condition {
+		t.Fatalf("unexpected conditions")
+	}
+	details := asserts["within"].Details
+	if details["left"] != "2024-01-02T03:04:06.5Z" || details["difference"] != "-1.5s" || details["max_skew"] != "1s" {
+		t.Fatalf("unexpected details: %v", details)
+	}
+	if details := asserts["lease"].Details; details["left"] != "1.5s" || details["right"] != "2s" {
+		t.Fatalf("expected durations to be readable, got %v", details)
+	}
+	if data, ok := guidance["lease"].Data.(map[string]any); !ok || data["left"] != float64(1.5e9) {
+		t.Fatalf("expected guidance on nanoseconds, got %v", guidance["lease"].Data)
+	}
+	if data, ok := guidance["within"].Data.(map[string]any); !ok || data["left"] != float64(1.5e9) || data["right"] != float64(1e9) {
+		t.Fatalf("unexpected guidance for SometimesWithin: %v", guidance["within"].Data)
+	}
+}
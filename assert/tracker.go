@@ -3,52 +3,270 @@
 package assert
 
 import (
+	"log"
+	"os"
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/antithesishq/antithesis-sdk-go/internal"
 )
 
+// rateWindow counts the emissions made within the current one-second window.
+// A window is replaced, rather than modified, once it is over, so that it
+// can be shared without a lock.
+type rateWindow struct {
+	current atomic.Pointer[windowCount]
+}
+
+type windowCount struct {
+	start time.Time
+	count atomic.Int64
+}
+
+// trackerInfo is created once per property and only its counts change
+// afterwards. The counts are atomic so that, whatever the emit policy,
+// evaluations after the first of each outcome take no lock. The first
+// evaluation of each outcome takes mutex, so that it is emitted only once.
 type trackerInfo struct {
-	Filename  string
-	Classname string
-	PassCount int
-	FailCount int
+	mutex       sync.Mutex
+	passWindow  rateWindow
+	failWindow  rateWindow
+	Location    locationInfo
+	Filename    string
+	Classname   string
+	Message     string
+	AssertType  string
+	DisplayType string
+	PassCount   atomic.Int64
+	FailCount   atomic.Int64
+	Occurrences atomic.Int64 // Conditions found true by SometimesAtLeast or AlwaysAtMost
+	MustHit     bool
+
+	// conflictReported is set once an evaluation of a different assertion
+	// type has been seen, so that later ones skip the diagnostic and its lock
+	conflictReported atomic.Bool
 }
 
-type emitTracker map[string]*trackerInfo
+// emitTracker maps the id of each property to its *trackerInfo
+type emitTracker struct {
+	entries sync.Map
+}
 
 // assert_tracker (global) keeps track of the unique asserts evaluated
+var assertTracker = &emitTracker{}
+
+// emitPolicies is never modified once it is published, so that it can be
+// read without a lock. Updates are serialized by emitPoliciesMutex.
+type emitPolicies struct {
+	global     EmitPolicy
+	properties map[string]EmitPolicy
+}
+
 var (
-	assertTracker    emitTracker = make(emitTracker)
-	trackerMutex     sync.Mutex
-	trackerInfoMutex sync.Mutex
+	currentEmitPolicies atomic.Pointer[emitPolicies]
+	emitPoliciesMutex   sync.Mutex
+	stackTraceMode      atomic.Int32
 )
 
-func (tracker emitTracker) getTrackerEntry(messageKey string, filename, classname string) *trackerInfo {
-	var trackerEntry *trackerInfo
-	var ok bool
+func init() {
+	internal.RegisterReset(resetTrackers)
+
+	policies := &emitPolicies{global: EmitFirst(), properties: map[string]EmitPolicy{}}
+	if text, ok := os.LookupEnv(internal.EmitPolicyEnvVar); ok {
+		global, properties, err := parseEmitPolicies(text)
+		if err != nil {
+			log.Printf("%s %s: %v", internal.ErrorLogLinePrefix, internal.EmitPolicyEnvVar, err)
+		}
+		if global != nil {
+			policies.global = *global
+		}
+		policies.properties = properties
+	}
+	currentEmitPolicies.Store(policies)
+
+	if text, ok := os.LookupEnv(internal.StackTraceEnvVar); ok {
+		mode, err := parseStackTraceMode(text)
+		if err != nil {
+			log.Printf("%s %s: %v", internal.ErrorLogLinePrefix, internal.StackTraceEnvVar, err)
+		}
+		stackTraceMode.Store(int32(mode))
+	}
+}
+
+// updateEmitPolicies publishes a modified copy of the current policies
+func updateEmitPolicies(update func(policies *emitPolicies)) {
+	emitPoliciesMutex.Lock()
+	defer emitPoliciesMutex.Unlock()
+	current := currentEmitPolicies.Load()
+	policies := &emitPolicies{
+		global:     current.global,
+		properties: make(map[string]EmitPolicy, len(current.properties)),
+	}
+	for message, policy := range current.properties {
+		policies.properties[message] = policy
+	}
+	update(policies)
+	currentEmitPolicies.Store(policies)
+}
+
+// SetEmitPolicy sets the policy used for every property that does not have a policy of its own, and returns the policy it replaces.
+//
+// The initial policy is EmitFirst, unless it is set by the environment variable ANTITHESIS_SDK_EMIT_POLICY. That variable holds entries separated by semicolons: an entry such as "every:100" sets the policy for every property, and an entry such as "disk full=all" sets the policy for the properties with the message "disk full". Policies are written as "first", "all", "every:N" or "rate:N".
+func SetEmitPolicy(policy EmitPolicy) EmitPolicy {
+	var previous EmitPolicy
+	updateEmitPolicies(func(policies *emitPolicies) {
+		previous = policies.global
+		policies.global = policy
+	})
+	return previous
+}
+
+// SetPropertyEmitPolicy sets the policy used for the properties with the given message, in place of the policy set by SetEmitPolicy.
+func SetPropertyEmitPolicy(message string, policy EmitPolicy) {
+	updateEmitPolicies(func(policies *emitPolicies) {
+		policies.properties[message] = policy
+	})
+}
+
+// ClearPropertyEmitPolicy removes the policy set for the properties with the given message by SetPropertyEmitPolicy, so that they use the policy set by SetEmitPolicy.
+func ClearPropertyEmitPolicy(message string) {
+	updateEmitPolicies(func(policies *emitPolicies) {
+		delete(policies.properties, message)
+	})
+}
+
+// SetStackTraceMode sets whether a stack trace is captured for the first failing evaluation of each Always, AlwaysOrUnreachable or Unreachable property, and returns the mode it replaces. The trace is added to the details of that evaluation with the key StackTraceKey, and is truncated if it is very large.
+//
+// The initial mode is StackTraceNone, unless it is set by the environment variable ANTITHESIS_SDK_STACK_TRACES to "none", "goroutine" or "all".
+func SetStackTraceMode(mode StackTraceMode) StackTraceMode {
+	return StackTraceMode(stackTraceMode.Swap(int32(mode)))
+}
+
+func captureStackTrace() string {
+	var buf []byte
+	switch StackTraceMode(stackTraceMode.Load()) {
+	case StackTraceGoroutine:
+		buf = make([]byte, maxGoroutineStackTraceBytes)
+		buf = buf[:runtime.Stack(buf, false)]
+	case StackTraceAll:
+		buf = make([]byte, maxAllStackTracesBytes)
+		buf = buf[:runtime.Stack(buf, true)]
+	default:
+		return ""
+	}
+	if len(buf) == cap(buf) {
+		return string(buf) + "\n... (truncated)"
+	}
+	return string(buf)
+}
 
+func emitPolicyFor(message string) EmitPolicy {
+	policies := currentEmitPolicies.Load()
+	if len(policies.properties) > 0 {
+		if policy, ok := policies.properties[message]; ok {
+			return policy
+		}
+	}
+	return policies.global
+}
+
+// policyMessage is the message that selects the emit policy of an assertion.
+// The buckets of a SometimesEach property share the policy of their property.
+func (ai *assertInfo) policyMessage() string {
+	if ai.Bucket != nil {
+		return ai.Bucket.BaseMessage
+	}
+	return ai.Message
+}
+
+// shouldEmit decides whether an evaluation is emitted, given how many
+// evaluations with the same outcome were seen before it. The first
+// evaluation of each outcome is always emitted, and is not decided here.
+func (p EmitPolicy) shouldEmit(count int64, window *rateWindow) bool {
+	switch p.kind {
+	case emitAll:
+		return true
+	case emitEveryNth:
+		return count%int64(p.n) == 0
+	case emitRateLimited:
+		return window.allow(time.Now(), p.perSecond)
+	}
+	return false
+}
+
+// restart starts a new window with one emission counted
+func (w *rateWindow) restart(now time.Time) {
+	next := &windowCount{start: now}
+	next.count.Store(1)
+	w.current.Store(next)
+}
+
+// allow counts an emission within the current window, starting a new
+// window if it is over, and reports whether the window has room for it
+func (w *rateWindow) allow(now time.Time, perSecond int) bool {
+	current := w.current.Load()
+	for current == nil || now.Sub(current.start) >= time.Second {
+		next := &windowCount{start: now}
+		if w.current.CompareAndSwap(current, next) {
+			current = next
+			break
+		}
+		current = w.current.Load()
+	}
+	return current.count.Add(1) <= int64(perSecond)
+}
+
+// resetTrackers forgets every assertion and guidance evaluation seen so far,
+// so that the next evaluation of each is emitted as if it were the first.
+func resetTrackers() {
+	clearSyncMap(&assertTracker.entries)
+	clearSyncMap(&numeric_guidance_tracker.entries)
+	clearSyncMap(&boolean_guidance_tracker.entries)
+	clearSyncMap(&explore_guidance_tracker.entries)
+	clearSyncMap(&pareto_guidance_tracker.entries)
+	clearSyncMap(&guidance_location_tracker.entries)
+
+	resetPropertyKinds()
+	resetBuckets()
+	resetEvents()
+}
+
+func clearSyncMap(m *sync.Map) {
+	m.Range(func(key, _ any) bool {
+		m.Delete(key)
+		return true
+	})
+}
+
+func (tracker *emitTracker) getTrackerEntry(messageKey string, ai *assertInfo) *trackerInfo {
 	if tracker == nil {
 		return nil
 	}
 
-	trackerMutex.Lock()
-	defer trackerMutex.Unlock()
-	if trackerEntry, ok = tracker[messageKey]; !ok {
-		trackerEntry = newTrackerInfo(filename, classname)
-		tracker[messageKey] = trackerEntry
+	if entry, ok := tracker.entries.Load(messageKey); ok {
+		return entry.(*trackerInfo)
+	}
+	entry, loaded := tracker.entries.LoadOrStore(messageKey, newTrackerInfo(ai))
+
+	if !loaded && useLocationIds {
+		// Distinct call sites get distinct entries, so a conflicting
+		// reuse of a message can only be spotted when an entry is created
+		checkPropertyKind(ai)
 	}
-	return trackerEntry
+	return entry.(*trackerInfo)
 }
 
-func newTrackerInfo(filename, classname string) *trackerInfo {
+func newTrackerInfo(ai *assertInfo) *trackerInfo {
 	trackerInfo := trackerInfo{
-		PassCount: 0,
-		FailCount: 0,
-		Filename:  filename,
-		Classname: classname,
+		Location:    *ai.Location,
+		Filename:    ai.Location.Filename,
+		Classname:   ai.Location.Classname,
+		Message:     ai.Message,
+		AssertType:  ai.AssertType,
+		DisplayType: ai.DisplayType,
+		MustHit:     ai.MustHit,
 	}
 	return &trackerInfo
 }
@@ -65,25 +283,36 @@ func (ti *trackerInfo) emit(ai *assertInfo) {
 		return
 	}
 
-	var err error
 	cond := ai.Condition
+	count, window := &ti.PassCount, &ti.passWindow
+	if !cond {
+		count, window = &ti.FailCount, &ti.failWindow
+	}
+	policy := emitPolicyFor(ai.policyMessage())
 
-	trackerInfoMutex.Lock()
-	defer trackerInfoMutex.Unlock()
-	if cond {
-		if ti.PassCount == 0 {
-			err = emitAssert(ai)
+	// The first evaluation of each outcome is emitted under the lock, and
+	// is only counted once it has been emitted successfully
+	if count.Load() == 0 {
+		ti.mutex.Lock()
+		if count.Load() == 0 {
+			defer ti.mutex.Unlock()
+			window.restart(time.Now())
+			// Only failures of Sometimes properties are expected
+			if !cond && ai.AssertType != existentialTest {
+				ai.stackTrace = captureStackTrace()
+			}
+			if emitAssert(ai) == nil {
+				count.Add(1)
+			}
+			return
 		}
-		if err == nil {
-			ti.PassCount++
-		}
-		return
+		ti.mutex.Unlock()
 	}
-	if ti.FailCount == 0 {
-		err = emitAssert(ai)
-	}
-	if err == nil {
-		ti.FailCount++
+
+	// Every later evaluation is counted, and the policy
+	// decides without a lock whether it is emitted
+	if n := count.Add(1) - 1; policy.shouldEmit(n, window) {
+		emitAssert(ai)
 	}
 }
 
@@ -107,5 +336,18 @@ func emitAssert(ai *assertInfo) error {
 	if hasEmitted.CompareAndSwap(false, true) {
 		versionMessage()
 	}
+	if ai.detailsFn != nil {
+		ai.Details = ai.detailsFn()
+		ai.detailsFn = nil
+	}
+	if ai.stackTrace != "" {
+		details := make(map[string]any, len(ai.Details)+1)
+		for k, v := range ai.Details {
+			details[k] = v
+		}
+		details[StackTraceKey] = ai.stackTrace
+		ai.Details = details
+	}
+	ai.Details = addGoroutineContext(ai.Details)
 	return internal.Json_data(wrappedAssertInfo{ai})
 }
@@ -0,0 +1,148 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// The benchmarks below evaluate assertions, events and guidance from many
+// goroutines at once, where only the first evaluation of each outcome is
+// emitted and the others take no lock. Run them with more than one CPU,
+// for example with -cpu 1,4,16, to see how they scale.
+
+func BenchmarkAlwaysParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			Always(true, "benchmark always", nil)
+		}
+	})
+}
+
+func BenchmarkAlwaysParallelDistinct(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		message := fmt.Sprintf("benchmark always %d", next.Add(1))
+		for pb.Next() {
+			Always(true, message, nil)
+		}
+	})
+}
+
+func BenchmarkNumericGuidanceParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			AlwaysLessThan(1, 2, "benchmark numeric guidance", nil)
+		}
+	})
+}
+
+func BenchmarkTrackAssertInfoParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	loc := newLocationInfo(offsetHere)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			assertImpl(true, "benchmark tracker", nil, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, "benchmark tracker")
+		}
+	})
+}
+
+func BenchmarkTrackAssertInfoEveryNthParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	SetPropertyEmitPolicy("benchmark every nth", EmitEveryNth(1000000))
+	b.Cleanup(func() { ClearPropertyEmitPolicy("benchmark every nth") })
+	loc := newLocationInfo(offsetHere)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			assertImpl(true, "benchmark every nth", nil, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, "benchmark every nth")
+		}
+	})
+}
+
+func BenchmarkTrackAssertInfoRateLimitedParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	SetPropertyEmitPolicy("benchmark rate limited", EmitRateLimited(1))
+	b.Cleanup(func() { ClearPropertyEmitPolicy("benchmark rate limited") })
+	loc := newLocationInfo(offsetHere)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			assertImpl(true, "benchmark rate limited", nil, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, "benchmark rate limited")
+		}
+	})
+}
+
+func BenchmarkTrackBucketParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		text := fmt.Sprintf("{worker=%d}", next.Add(1))
+		for pb.Next() {
+			trackBucket("benchmark buckets", text)
+		}
+	})
+}
+
+func BenchmarkEventParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		key := fmt.Sprintf("benchmark key %d", next.Add(1))
+		for pb.Next() {
+			Event(key, "benchmark event")
+		}
+	})
+}
+
+func BenchmarkExploreParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	loc := newLocationInfo(offsetHere)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			exploreGuidanceImpl("stable", "benchmark explore", "benchmark explore", loc, wasHit)
+		}
+	})
+}
+
+func BenchmarkParetoParallel(b *testing.B) {
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	loc := newLocationInfo(offsetHere)
+	dimensions := []Dimension{MaximizeDimension("load", 1), MinimizeDimension("free_disk", 1)}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			paretoGuidanceImpl(dimensions, "benchmark pareto", "benchmark pareto", loc, wasHit)
+		}
+	})
+}
+
+func BenchmarkConflictingAssertionParallel(b *testing.B) {
+	output := captureOutput(b)
+	resetTrackers()
+	b.Cleanup(resetTrackers)
+	loc := newLocationInfo(offsetHere)
+	assertImpl(true, "benchmark conflict", nil, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, "benchmark conflict")
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			assertImpl(true, "benchmark conflict", nil, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, "benchmark conflict")
+		}
+	})
+	b.StopTimer()
+	if diagnostics := output.count(diagnosticPrefix); diagnostics != 1 {
+		b.Fatalf("expected one diagnostic for the conflict, got %d", diagnostics)
+	}
+}
@@ -35,6 +35,7 @@ let
         replaceWithLink('assert')
         replaceWithLink('random')
         replaceWithLink('lifecycle')
+        replaceWithLink('handler')
       </script>
       
       </body>
@@ -50,7 +51,7 @@ let
       export HOME=$TMPDIR
       mkdir -p $out/docs
       # TODO: can add `-emded` to generate basic stubs for the docs with no styling to customize our own
-      doc2go -home github.com/antithesishq/antithesis-sdk-go -out $out/docs ./assert ./random ./lifecycle
+      doc2go -home github.com/antithesishq/antithesis-sdk-go -out $out/docs ./assert ./random ./lifecycle ./handler
       pandoc --template ${index_template} -o $out/index.html README.md
     '';
   };
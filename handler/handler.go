@@ -0,0 +1,44 @@
+//go:build !no_antithesis_sdk
+
+// Package handler lets a program choose where the output of the SDK is sent. It is part of the [Antithesis Go SDK], which enables Go applications to integrate with the [Antithesis platform].
+//
+// By default, the SDK sends assertions, guidance and lifecycle events to the Antithesis platform when running within Antithesis, or to the file named by ANTITHESIS_SDK_LOCAL_OUTPUT otherwise. Calling [Set] replaces that default with any [Handler], such as one that forwards to a logging pipeline, a channel, or a test double.
+//
+// A replacement handler usually chains back to the default handler for anything it does not handle itself. Embedding the value returned by [Default] is the simplest way to do so:
+//
+//	type teeHandler struct {
+//		handler.Handler
+//		messages chan<- string
+//	}
+//
+//	func (h *teeHandler) Output(message string) {
+//		h.messages <- message
+//		h.Handler.Output(message)
+//	}
+//
+//	handler.Set(&teeHandler{handler.Default(), messages})
+//
+// [Set], [Get] and [Default] are safe to call concurrently with each other and with the rest of the SDK. Once Set returns, every subsequent output of the SDK is sent to the new handler, although output that was already in progress may still complete on the previous one.
+//
+// [Antithesis Go SDK]: https://antithesis.com/docs/using_antithesis/sdk/go_sdk.html
+// [Antithesis platform]: https://antithesis.com
+package handler
+
+import (
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// Set makes h the handler for all output of the SDK, and returns the handler it replaces. Calling Set with nil restores the default handler.
+func Set(h Handler) Handler {
+	return internal.SetHandler(h)
+}
+
+// Get returns the handler currently in use.
+func Get() Handler {
+	return internal.ActiveHandler()
+}
+
+// Default returns the handler selected by the SDK when the process started: the Antithesis platform when running within Antithesis, and the local handler otherwise.
+func Default() Handler {
+	return internal.DefaultHandler()
+}
@@ -0,0 +1,7 @@
+//go:build no_antithesis_sdk
+
+package handler
+
+func Set(h Handler) Handler { return nil }
+func Get() Handler          { return nil }
+func Default() Handler      { return nil }
@@ -0,0 +1,51 @@
+//go:build !no_antithesis_sdk
+
+package handler
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/lifecycle"
+)
+
+type recordingHandler struct {
+	Handler
+	messages []string
+	mutex    sync.Mutex
+}
+
+func (h *recordingHandler) Output(message string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.messages = append(h.messages, message)
+}
+
+func TestSetHandler(t *testing.T) {
+	recorder := &recordingHandler{Handler: Default()}
+	previous := Set(recorder)
+	defer Set(previous)
+
+	if Get() != Handler(recorder) {
+		t.Fatalf("Set did not install the handler")
+	}
+
+	lifecycle.SendEvent("handler_test", map[string]any{"n": 1})
+	if len(recorder.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(recorder.messages))
+	}
+	if want := `{"handler_test":{"n":1}}`; recorder.messages[0] != want {
+		t.Fatalf("got %s, want %s", recorder.messages[0], want)
+	}
+
+	// Methods not overridden chain to the default handler
+	recorder.Notify(1)
+}
+
+func TestSetNilRestoresDefault(t *testing.T) {
+	Set(&recordingHandler{Handler: Default()})
+	Set(nil)
+	if Get() != Default() {
+		t.Fatalf("Set(nil) did not restore the default handler")
+	}
+}
@@ -0,0 +1,15 @@
+package handler
+
+// Handler receives everything the SDK outputs, and provides the SDK with random values and coverage support.
+//
+// Output is called with a single JSON document for each assertion, guidance record and lifecycle event. Random is called by the [random] package. Notify and InitCoverage are called by code instrumented with the antithesis-go-instrumentor.
+//
+// The methods of a Handler may be called concurrently from many goroutines, so implementations must be safe for concurrent use. Output may be called while the SDK holds internal locks, so it must not call back into the assert, lifecycle or random packages.
+//
+// [random]: https://antithesis.com/docs/generated/sdk/golang/random/
+type Handler interface {
+	Output(message string)
+	Random() uint64
+	Notify(edge uint64) bool
+	InitCoverage(numEdges uint64, symbols string) uint64
+}
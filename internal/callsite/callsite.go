@@ -0,0 +1,21 @@
+// Package callsite names the call sites of assertions from the names of
+// functions reported by the runtime. It is shared by the assert package
+// and its compat package, so that both name the locations of their
+// assertions in the same way.
+package callsite
+
+import (
+	"path"
+	"strings"
+)
+
+// SplitFuncName splits the full name of a function, such as
+// "example.com/pkg.(*Server).Serve", into its class and function names
+func SplitFuncName(fullname string) (classname, funcname string) {
+	funcname = path.Ext(fullname)
+	classname, _ = strings.CutSuffix(fullname, funcname)
+	if funcname == "" {
+		return classname, "*function*"
+	}
+	return classname, funcname[1:]
+}
@@ -0,0 +1,12 @@
+package callsite
+
+import "testing"
+
+func TestSplitFuncName(t *testing.T) {
+	if classname, funcname := SplitFuncName("example.com/pkg.(*Server).Serve"); classname != "example.com/pkg.(*Server)" || funcname != "Serve" {
+		t.Fatalf("unexpected names %q and %q", classname, funcname)
+	}
+	if classname, funcname := SplitFuncName(""); classname != "" || funcname != "*function*" {
+		t.Fatalf("unexpected names %q and %q for an unnamed function", classname, funcname)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"math/rand"
 	"os"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -69,36 +70,82 @@ func Json_data(v any) error {
 	if data, err := json.Marshal(v); err != nil {
 		return err
 	} else {
-		handler.output(string(data))
+		ActiveHandler().Output(string(data))
 		return nil
 	}
 }
 
 func Get_random() uint64 {
-	return handler.random()
+	return ActiveHandler().Random()
 }
 
 func Notify(edge uint64) bool {
-	return handler.notify(edge)
+	return ActiveHandler().Notify(edge)
 }
 
 func InitCoverage(num_edges uint64, symbols string) uint64 {
-	return handler.init_coverage(num_edges, symbols)
+	return ActiveHandler().InitCoverage(num_edges, symbols)
 }
 
-type libHandler interface {
-	output(message string)
-	random() uint64
-	notify(edge uint64) bool
-	init_coverage(num_edges uint64, symbols string) uint64
+// CaptureOutput diverts every message written with Json_data to capture
+// until the returned restore function is called. Random values, coverage
+// notifications and coverage initialization are still served by the
+// handler that was active when CaptureOutput was called.
+//
+// This is intended for in-process test helpers, and is not safe to use
+// concurrently with other calls to CaptureOutput.
+func CaptureOutput(capture func(message string)) (restore func()) {
+	previous := ActiveHandler()
+	SetHandler(&captureHandler{previous, capture})
+	return func() {
+		SetHandler(previous)
+	}
+}
+
+// Handler receives everything the SDK outputs, and provides the SDK
+// with random values and coverage support.
+type Handler interface {
+	Output(message string)
+	Random() uint64
+	Notify(edge uint64) bool
+	InitCoverage(num_edges uint64, symbols string) uint64
+}
+
+// SetHandler makes h the active handler and returns the handler it replaces.
+// Passing nil restores the default handler.
+func SetHandler(h Handler) Handler {
+	if h == nil {
+		h = defaultHandler
+	}
+	return currentHandler.Swap(&handlerHolder{h}).h
+}
+
+// DefaultHandler returns the handler selected when the process started:
+// either libvoidstar, or the local handler.
+func DefaultHandler() Handler {
+	return defaultHandler
+}
+
+// ActiveHandler returns the handler currently receiving output.
+func ActiveHandler() Handler {
+	return currentHandler.Load().h
 }
 
 const (
-	errorLogLinePrefix       = "[* antithesis-sdk-go *]"
+	errorLogLinePrefix       = ErrorLogLinePrefix
 	defaultNativeLibraryPath = "/usr/lib/libvoidstar.so"
 )
 
-var handler libHandler
+// handlerHolder lets the active handler be swapped atomically,
+// regardless of the concrete type of the handler.
+type handlerHolder struct {
+	h Handler
+}
+
+var (
+	defaultHandler Handler
+	currentHandler atomic.Pointer[handlerHolder]
+)
 
 type voidstarHandler struct {
 	fuzzJsonData   unsafe.Pointer
@@ -108,7 +155,7 @@ type voidstarHandler struct {
 	notifyCoverage unsafe.Pointer
 }
 
-func (h *voidstarHandler) output(message string) {
+func (h *voidstarHandler) Output(message string) {
 	msg_len := len(message)
 	if msg_len == 0 {
 		return
@@ -119,17 +166,17 @@ func (h *voidstarHandler) output(message string) {
 	C.go_fuzz_flush(h.fuzzFlush)
 }
 
-func (h *voidstarHandler) random() uint64 {
+func (h *voidstarHandler) Random() uint64 {
 	return uint64(C.go_fuzz_get_random(h.fuzzGetRandom))
 }
 
-func (h *voidstarHandler) init_coverage(num_edge uint64, symbols string) uint64 {
+func (h *voidstarHandler) InitCoverage(num_edge uint64, symbols string) uint64 {
 	cstrSymbols := C.CString(symbols)
 	defer C.free(unsafe.Pointer(cstrSymbols))
 	return uint64(C.go_init_coverage(h.initCoverage, C.ulong(num_edge), cstrSymbols))
 }
 
-func (h *voidstarHandler) notify(edge uint64) bool {
+func (h *voidstarHandler) Notify(edge uint64) bool {
 	ival := int(C.go_notify_coverage(h.notifyCoverage, C.ulong(edge)))
 	return ival == 1
 }
@@ -138,7 +185,7 @@ type localHandler struct {
 	outputFile *os.File // can be nil
 }
 
-func (h *localHandler) output(message string) {
+func (h *localHandler) Output(message string) {
 	msg_len := len(message)
 	if msg_len == 0 {
 		return
@@ -148,29 +195,42 @@ func (h *localHandler) output(message string) {
 	}
 }
 
-func (h *localHandler) random() uint64 {
+func (h *localHandler) Random() uint64 {
 	return rand.Uint64()
 }
 
-func (h *localHandler) notify(edge uint64) bool {
+func (h *localHandler) Notify(edge uint64) bool {
 	return false
 }
 
-func (h *localHandler) init_coverage(num_edges uint64, symbols string) uint64 {
+func (h *localHandler) InitCoverage(num_edges uint64, symbols string) uint64 {
 	return 0
 }
 
+type captureHandler struct {
+	Handler
+	capture func(message string)
+}
+
+func (h *captureHandler) Output(message string) {
+	if len(message) == 0 {
+		return
+	}
+	h.capture(message)
+}
+
 // If we have a file at `defaultNativeLibraryPath`, we load the shared library
 // (and panic on any error encountered during load).
 // Otherwise fallback to the local handler.
 func init() {
 	if _, err := os.Stat(defaultNativeLibraryPath); err == nil {
-		if handler, err = openSharedLib(defaultNativeLibraryPath); err != nil {
+		if defaultHandler, err = openSharedLib(defaultNativeLibraryPath); err != nil {
 			panic(err)
 		}
-		return
+	} else {
+		defaultHandler = openLocalHandler()
 	}
-	handler = openLocalHandler()
+	currentHandler.Store(&handlerHolder{defaultHandler})
 }
 
 // Attempt to load libvoidstar and some symbols from `path`
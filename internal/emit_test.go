@@ -14,11 +14,11 @@ func TestLocalHandlerFileOutput(t *testing.T) {
 	path := os.TempDir() + string(os.PathSeparator) + "antithesis-test.log"
 	os.Setenv(localOutputEnvVar, path)
 	defer os.Unsetenv(localOutputEnvVar)
-	handler = openLocalHandler()
+	SetHandler(openLocalHandler())
 	Json_data(map[string]string{
 		"test": "output",
 	})
-	handler.(*localHandler).outputFile.Close()
+	ActiveHandler().(*localHandler).outputFile.Close()
 	data, err := os.ReadFile(path)
 	if err != nil {
 		panic(err)
@@ -35,11 +35,11 @@ func TestLocalHandlerFileOutput(t *testing.T) {
 func TestLocalHandlerNop(t *testing.T) {
 	os.Setenv(localOutputEnvVar, "")
 	defer os.Unsetenv(localOutputEnvVar)
-	handler = openLocalHandler()
+	SetHandler(openLocalHandler())
 	Json_data(map[string]string{
 		"test": "output",
 	})
-	h, valid := handler.(*localHandler)
+	h, valid := ActiveHandler().(*localHandler)
 	if !valid {
 		panic("Not using the local handler")
 	}
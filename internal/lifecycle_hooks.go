@@ -0,0 +1,36 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import "sync"
+
+var (
+	lifecycleHooks      []func()
+	lifecycleHooksMutex sync.Mutex
+)
+
+// RegisterLifecycleHook records fn to be called by RunLifecycleHooks.
+// The lifecycle package runs these hooks whenever it reports a milestone,
+// which lets other packages (such as assert, for its registered
+// invariants) react without the lifecycle package depending on them.
+func RegisterLifecycleHook(fn func()) {
+	if fn == nil {
+		return
+	}
+	lifecycleHooksMutex.Lock()
+	defer lifecycleHooksMutex.Unlock()
+	lifecycleHooks = append(lifecycleHooks, fn)
+}
+
+// RunLifecycleHooks calls every function registered with
+// RegisterLifecycleHook, in the order they were registered.
+func RunLifecycleHooks() {
+	lifecycleHooksMutex.Lock()
+	hooks := make([]func(), len(lifecycleHooks))
+	copy(hooks, lifecycleHooks)
+	lifecycleHooksMutex.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
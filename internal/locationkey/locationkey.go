@@ -0,0 +1,23 @@
+// Package locationkey composes the ids of assertions built with the
+// antithesis_location_ids tag. It has no dependencies, so that the
+// assertion scanner can share it with the assert package without
+// linking the runtime support of the SDK.
+package locationkey
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Key composes the id of an assertion from its message and the file and
+// line of its call site. The file is named by the import path of its
+// package followed by its base name, since the directory an assertion is
+// compiled from is not the same as the directory it is cataloged from.
+//
+// The assert package and the assertion scanner both use Key, so that ids
+// generated for the catalog match the ids used at runtime.
+func Key(message, packagePath, filename string, line int) string {
+	basename := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	return fmt.Sprintf("%s [%s/%s:%d]", message, packagePath, basename, line)
+}
@@ -0,0 +1,36 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import "sync"
+
+var (
+	resetHooks      []func()
+	resetHooksMutex sync.Mutex
+)
+
+// RegisterReset records fn to be called by Reset. Packages that keep
+// per-process state about what has already been emitted (such as the
+// assertion trackers) register here so that test helpers can start
+// from a clean slate.
+func RegisterReset(fn func()) {
+	if fn == nil {
+		return
+	}
+	resetHooksMutex.Lock()
+	defer resetHooksMutex.Unlock()
+	resetHooks = append(resetHooks, fn)
+}
+
+// Reset calls every function registered with RegisterReset, in the
+// order they were registered.
+func Reset() {
+	resetHooksMutex.Lock()
+	hooks := make([]func(), len(resetHooks))
+	copy(hooks, resetHooks)
+	resetHooksMutex.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
@@ -10,3 +10,11 @@ const Protocol_Version = "1.1.0"
 // Environment Vars
 // --------------------------------------------------------------------------------
 const localOutputEnvVar = "ANTITHESIS_SDK_LOCAL_OUTPUT"
+const EmitPolicyEnvVar = "ANTITHESIS_SDK_EMIT_POLICY"
+const StackTraceEnvVar = "ANTITHESIS_SDK_STACK_TRACES"
+const GoroutineContextEnvVar = "ANTITHESIS_SDK_GOROUTINE_CONTEXT"
+
+// --------------------------------------------------------------------------------
+// Logging
+// --------------------------------------------------------------------------------
+const ErrorLogLinePrefix = "[* antithesis-sdk-go *]"
@@ -4,6 +4,8 @@
 //
 // Both functions take the parameter details: Optional additional information provided by the user to add context for assertion failures. The information that is logged will appear in the logs section of a [triage report]. Normally the values passed to details are evaluated at runtime.
 //
+// Both functions also evaluate the invariants registered with assert.RegisterInvariant, if any.
+//
 // [Antithesis Go SDK]: https://antithesis.com/docs/using_antithesis/sdk/go_sdk.html
 // [Antithesis platform]: https://antithesis.com
 // [triage report]: https://antithesis.com/docs/reports/triage.html
@@ -24,6 +26,7 @@ func SetupComplete(details any) {
 		"details": details,
 	}
 	internal.Json_data(map[string]any{"antithesis_setup": statusBlock})
+	internal.RunLifecycleHooks()
 }
 
 // SendEvent indicates to Antithesis that a certain event has been reached. It provides greater information about the ordering of events during the course of testing in Antithesis.
@@ -33,4 +36,5 @@ func SetupComplete(details any) {
 // [triage report]: https://antithesis.com/docs/reports/triage.html
 func SendEvent(eventName string, details any) {
 	internal.Json_data(map[string]any{eventName: details})
+	internal.RunLifecycleHooks()
 }
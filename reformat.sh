@@ -1,6 +1,9 @@
 #! /bin/sh
 set -e
 go fmt -x github.com/antithesishq/antithesis-sdk-go/assert
+go fmt -x github.com/antithesishq/antithesis-sdk-go/assert/asserttest
+go fmt -x github.com/antithesishq/antithesis-sdk-go/assert/compat
+go fmt -x github.com/antithesishq/antithesis-sdk-go/handler
 go fmt -x github.com/antithesishq/antithesis-sdk-go/instrumentation
 go fmt -x github.com/antithesishq/antithesis-sdk-go/internal
 go fmt -x github.com/antithesishq/antithesis-sdk-go/lifecycle
@@ -13,6 +16,9 @@ go fmt -x github.com/antithesishq/antithesis-sdk-go/tools/antithesis-go-instrume
 go fmt -x github.com/antithesishq/antithesis-sdk-go/tools/antithesis-go-instrumentor/assertions
 
 go build github.com/antithesishq/antithesis-sdk-go/assert
+go build github.com/antithesishq/antithesis-sdk-go/assert/asserttest
+go build github.com/antithesishq/antithesis-sdk-go/assert/compat
+go build github.com/antithesishq/antithesis-sdk-go/handler
 go build github.com/antithesishq/antithesis-sdk-go/lifecycle
 go build github.com/antithesishq/antithesis-sdk-go/internal
 go build github.com/antithesishq/antithesis-sdk-go/random
@@ -64,7 +64,7 @@ func main() {
 	cI := cmd_files.NewCoverageInstrumentor()
 	source_dir := cmd_files.GetSourceDir() // Where are the source files to be instrumented
 	target_dir := cmd_files.GetTargetDir() // Where will the final instrumented files be written
-	aScanner := assertions.NewAssertionScanner(logWriter.IsVerbose(), cI.FullCatalogPath, cI.UsingSymbols, source_dir, target_dir)
+	aScanner := assertions.NewAssertionScanner(logWriter.IsVerbose(), cI.FullCatalogPath, cI.UsingSymbols, source_dir, target_dir, cmd_args.LocationIds, cmd_files.GetModulePath())
 
 	//--------------------------------------------------------------------------------
 	// Process all files (ignore previously generated assertion catalogs)
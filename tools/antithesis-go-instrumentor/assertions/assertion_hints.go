@@ -12,17 +12,19 @@ const (
 	GuidanceFnWantAll                        // Encourages fuzzing explorations where boolean values are true
 	GuidanceFnWantNone                       // Encourages fuzzing explorations where boolean values are false
 	GuidanceFnExplore
+	GuidanceFnPareto
 )
 
 // --------------------------------------------------------------------------------
 // Assertion Hints
 // --------------------------------------------------------------------------------
 type AssertionFuncInfo struct {
-	TargetFunc string
-	AssertType string
-	MustHit    bool
-	Condition  bool
-	MessageArg int
+	TargetFunc  string
+	AssertType  string
+	DisplayType string // Optional, when the display type differs from TargetFunc
+	MustHit     bool
+	Condition   bool
+	MessageArg  int
 }
 
 type AssertionHints map[string]*AssertionFuncInfo
@@ -70,6 +72,112 @@ func SetupHintMap() AssertionHints {
 		MessageArg: 0,
 	}
 
+	addLazyHints(hintMap)
+
+	hintMap["AlwaysNoError"] = &AssertionFuncInfo{
+		TargetFunc:  "AlwaysNoError",
+		DisplayType: "Always",
+		MustHit:     true,
+		AssertType:  "always",
+		Condition:   false,
+		MessageArg:  1,
+	}
+
+	hintMap["SometimesError"] = &AssertionFuncInfo{
+		TargetFunc:  "SometimesError",
+		DisplayType: "Sometimes",
+		MustHit:     true,
+		AssertType:  "sometimes",
+		Condition:   false,
+		MessageArg:  1,
+	}
+
+	hintMap["SometimesErrorIs"] = &AssertionFuncInfo{
+		TargetFunc:  "SometimesErrorIs",
+		DisplayType: "Sometimes",
+		MustHit:     true,
+		AssertType:  "sometimes",
+		Condition:   false,
+		MessageArg:  2,
+	}
+
+	// Only the property named by the message is registered,
+	// since its buckets are only known at runtime
+	hintMap["SometimesEach"] = &AssertionFuncInfo{
+		TargetFunc:  "SometimesEach",
+		DisplayType: "Sometimes",
+		MustHit:     true,
+		AssertType:  "sometimes",
+		Condition:   false,
+		MessageArg:  0,
+	}
+
+	// Ordering rules are checked where they are declared
+	hintMap["HappensBefore"] = &AssertionFuncInfo{
+		TargetFunc:  "HappensBefore",
+		DisplayType: "Always",
+		MustHit:     true,
+		AssertType:  "always",
+		Condition:   false,
+		MessageArg:  2,
+	}
+
+	hintMap["NeverAfter"] = &AssertionFuncInfo{
+		TargetFunc:  "NeverAfter",
+		DisplayType: "Always",
+		MustHit:     true,
+		AssertType:  "always",
+		Condition:   false,
+		MessageArg:  2,
+	}
+
+	// Invariants are checked where they are registered
+	hintMap["RegisterInvariant"] = &AssertionFuncInfo{
+		TargetFunc:  "RegisterInvariant",
+		DisplayType: "Always",
+		MustHit:     true,
+		AssertType:  "always",
+		Condition:   false,
+		MessageArg:  0,
+	}
+
+	return hintMap
+}
+
+// --------------------------------------------------------------------------------
+// Compat Hints
+//
+// The functions of the assert/compat package are all Always assertions
+// --------------------------------------------------------------------------------
+func SetupCompatHintMap() AssertionHints {
+	hintMap := make(AssertionHints)
+
+	messageArgs := map[string]int{
+		"Equal":         2,
+		"NotEqual":      2,
+		"Nil":           1,
+		"NotNil":        1,
+		"True":          1,
+		"False":         1,
+		"Contains":      2,
+		"Len":           2,
+		"ElementsMatch": 2,
+		"NoError":       1,
+		"Error":         1,
+		"ErrorIs":       2,
+		"Eventually":    3,
+	}
+	for name, messageArg := range messageArgs {
+		hintMap[name] = &AssertionFuncInfo{
+			TargetFunc:  name,
+			DisplayType: "Always",
+			MustHit:     true,
+			AssertType:  "always",
+			Condition:   false,
+			MessageArg:  messageArg,
+		}
+	}
+
 	return hintMap
 }
 
@@ -196,9 +304,247 @@ func SetupGuidanceHintMap() GuidanceHints {
 		GuidanceFn: GuidanceFnWantAll,
 	}
 
+	hintMap["AlwaysAll"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysAll",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 1,
+		},
+		GuidanceFn: GuidanceFnWantNone,
+	}
+
+	hintMap["AlwaysNone"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysNone",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 1,
+		},
+		GuidanceFn: GuidanceFnWantAll,
+	}
+
+	hintMap["SometimesSome"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "SometimesSome",
+			AssertType: "sometimes",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 1,
+		},
+		GuidanceFn: GuidanceFnWantAll,
+	}
+
+	hintMap["SometimesNone"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "SometimesNone",
+			AssertType: "sometimes",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 1,
+		},
+		GuidanceFn: GuidanceFnWantNone,
+	}
+
+	addLazyGuidanceHints(hintMap)
+
+	hintMap["AlwaysEqual"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysEqual",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["SometimesEqual"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "SometimesEqual",
+			AssertType: "sometimes",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
+	hintMap["AlwaysNotEqual"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysNotEqual",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
+	hintMap["AlwaysApproxEqual"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysApproxEqual",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 3,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["AlwaysInRange"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysInRange",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 3,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
+	hintMap["SometimesInRange"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "SometimesInRange",
+			AssertType: "sometimes",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 3,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["AlwaysBefore"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysBefore",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["AlwaysAfter"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysAfter",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
+	hintMap["SometimesWithin"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "SometimesWithin",
+			AssertType: "sometimes",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 3,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
+	hintMap["SometimesAtLeast"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "SometimesAtLeast",
+			AssertType: "sometimes",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["AlwaysAtMost"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysAtMost",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	// Maximize and Minimize only provide guidance, and have no related assertion
+	hintMap["Maximize"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "Maximize",
+			MustHit:    false,
+			Condition:  false,
+			MessageArg: 1,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["Minimize"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "Minimize",
+			MustHit:    false,
+			Condition:  false,
+			MessageArg: 1,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
+	// Likewise for Explore
+	hintMap["Explore"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "Explore",
+			MustHit:    false,
+			Condition:  false,
+			MessageArg: 0,
+		},
+		GuidanceFn: GuidanceFnExplore,
+	}
+
+	hintMap["Pareto"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "Pareto",
+			MustHit:    false,
+			Condition:  false,
+			MessageArg: 0,
+		},
+		GuidanceFn: GuidanceFnPareto,
+	}
+
 	return hintMap
 }
 
+// Each assertion "X" has a variant "XLazy" which takes its details as a function.
+// The variant takes its message at the same position, and is registered as "X".
+func addLazyHints(hintMap AssertionHints) {
+	lazyHints := []*AssertionFuncInfo{}
+	for name, hints := range hintMap {
+		lazy := *hints
+		lazy.TargetFunc = name + "Lazy"
+		if lazy.DisplayType == "" {
+			lazy.DisplayType = name
+		}
+		lazyHints = append(lazyHints, &lazy)
+	}
+	for _, lazy := range lazyHints {
+		hintMap[lazy.TargetFunc] = lazy
+	}
+}
+
+func addLazyGuidanceHints(hintMap GuidanceHints) {
+	lazyHints := []*GuidanceFuncInfo{}
+	for name, hints := range hintMap {
+		lazy := *hints
+		lazy.TargetFunc = name + "Lazy"
+		lazyHints = append(lazyHints, &lazy)
+	}
+	for _, lazy := range lazyHints {
+		hintMap[lazy.TargetFunc] = lazy
+	}
+}
+
 func (m AssertionHints) HintsForName(name string) *AssertionFuncInfo {
 	if v, ok := m[name]; ok {
 		return v
@@ -8,10 +8,12 @@ import (
 	"go/types"
 	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/antithesishq/antithesis-sdk-go/internal/locationkey"
 	"github.com/antithesishq/antithesis-sdk-go/tools/antithesis-go-instrumentor/common"
 )
 
@@ -19,6 +21,7 @@ type AntExpect struct {
 	*AssertionFuncInfo
 	Assertion string
 	Message   string
+	Id        string
 	Classname string
 	Funcname  string
 	Receiver  string
@@ -26,10 +29,19 @@ type AntExpect struct {
 	Line      int
 }
 
+// DisplayName is the display type used to register the assertion in the catalog
+func (expect *AntExpect) DisplayName() string {
+	if expect.AssertionFuncInfo != nil && expect.AssertionFuncInfo.DisplayType != "" {
+		return expect.AssertionFuncInfo.DisplayType
+	}
+	return expect.Assertion
+}
+
 type AntGuidance struct {
 	*GuidanceFuncInfo
 	Assertion string
 	Message   string
+	Id        string
 	Classname string
 	Funcname  string
 	Receiver  string
@@ -41,6 +53,7 @@ type AntGuidance struct {
 type AssertionScanner struct {
 	assertionHintMap   AssertionHints
 	guidanceHintMap    GuidanceHints
+	compatHintMap      AssertionHints
 	fset               *token.FileSet
 	logWriter          *common.LogWriter
 	symbolTableName    string
@@ -55,8 +68,11 @@ type AssertionScanner struct {
 	expects            []*AntExpect
 	guidance           []*AntGuidance
 	imports            []string
+	compatImports      []string
 	filesCataloged     int
 	verbose            bool
+	locationIds        bool
+	modulePath         string
 }
 
 // filter Guidance to just numeric
@@ -71,6 +87,28 @@ func (aScanner *AssertionScanner) numericGuidance() []*AntGuidance {
 	return numeric_guidance
 }
 
+// filter Guidance to just explore
+func (aScanner *AssertionScanner) exploreGuidance() []*AntGuidance {
+	explore_guidance := []*AntGuidance{}
+	for _, aG := range aScanner.guidance {
+		if aG.GuidanceFn == GuidanceFnExplore {
+			explore_guidance = append(explore_guidance, aG)
+		}
+	}
+	return explore_guidance
+}
+
+// filter Guidance to just pareto
+func (aScanner *AssertionScanner) paretoGuidance() []*AntGuidance {
+	pareto_guidance := []*AntGuidance{}
+	for _, aG := range aScanner.guidance {
+		if aG.GuidanceFn == GuidanceFnPareto {
+			pareto_guidance = append(pareto_guidance, aG)
+		}
+	}
+	return pareto_guidance
+}
+
 // filter Guidance to just boolean
 func (aScanner *AssertionScanner) booleanGuidance() []*AntGuidance {
 	boolean_guidance := []*AntGuidance{}
@@ -83,7 +121,7 @@ func (aScanner *AssertionScanner) booleanGuidance() []*AntGuidance {
 	return boolean_guidance
 }
 
-func NewAssertionScanner(verbose bool, moduleName string, symbolTableName string, sourceDir string, targetDir string) *AssertionScanner {
+func NewAssertionScanner(verbose bool, moduleName string, symbolTableName string, sourceDir string, targetDir string, locationIds bool, modulePath string) *AssertionScanner {
 	logWriter := common.GetLogWriter()
 	if logWriter.VerboseLevel(2) {
 		logWriter.Printf(">> Module: %s\n", moduleName)
@@ -93,6 +131,7 @@ func NewAssertionScanner(verbose bool, moduleName string, symbolTableName string
 		moduleName:       moduleName,
 		fset:             token.NewFileSet(),
 		imports:          []string{},
+		compatImports:    []string{},
 		expects:          []*AntExpect{},
 		guidance:         []*AntGuidance{},
 		verbose:          verbose,
@@ -103,9 +142,12 @@ func NewAssertionScanner(verbose bool, moduleName string, symbolTableName string
 		baseTargetDir:    targetDir,
 		assertionHintMap: SetupHintMap(),
 		guidanceHintMap:  SetupGuidanceHintMap(),
+		compatHintMap:    SetupCompatHintMap(),
 		symbolTableName:  symbolTableName,
 		filesCataloged:   0,
 		logWriter:        logWriter,
+		locationIds:      locationIds,
+		modulePath:       modulePath,
 	}
 	return &aScanner
 }
@@ -129,7 +171,7 @@ func (aScanner *AssertionScanner) ScanFile(file_path string) {
 }
 
 func (aScanner *AssertionScanner) HasAssertionsDefined() bool {
-	return len(aScanner.expects) > 0
+	return len(aScanner.expects) > 0 || len(aScanner.guidance) > 0
 }
 
 func (aScanner *AssertionScanner) WriteAssertionCatalog(versionText string) {
@@ -145,16 +187,26 @@ func (aScanner *AssertionScanner) WriteAssertionCatalog(versionText string) {
 	boolean_guidance := aScanner.booleanGuidance()
 	has_boolean_guidance := len(boolean_guidance) > 0
 
+	explore_guidance := aScanner.exploreGuidance()
+	has_explore_guidance := len(explore_guidance) > 0
+
+	pareto_guidance := aScanner.paretoGuidance()
+	has_pareto_guidance := len(pareto_guidance) > 0
+
 	genInfo := GenInfo{
 		ExpectedVals:        expects,
 		NumericGuidanceVals: numeric_guidance,
 		BooleanGuidanceVals: boolean_guidance,
+		ExploreGuidanceVals: explore_guidance,
+		ParetoGuidanceVals:  pareto_guidance,
 		AssertPackageName:   common.AssertPackageName(),
 		VersionText:         versionText,
 		CreateDate:          createDate,
 		HasAssertions:       has_expects,
 		HasNumericGuidance:  has_numeric_guidance,
 		HasBooleanGuidance:  has_boolean_guidance,
+		HasExploreGuidance:  has_explore_guidance,
+		HasParetoGuidance:   has_pareto_guidance,
 		ConstMap:            aScanner.getConstMap(),
 		logWriter:           common.GetLogWriter(),
 	}
@@ -174,6 +226,7 @@ func (aScanner *AssertionScanner) reset_for_file(file_path string) {
 		aScanner.logWriter.Printf(">>     File: %s\n", file_path)
 	}
 	aScanner.imports = []string{}
+	aScanner.compatImports = []string{}
 	aScanner.funcName = ""
 	aScanner.packageName = ""
 	aScanner.receiver = ""
@@ -208,6 +261,7 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node) bool {
 	var path_name string
 
 	assertPackageName := common.AssertPackageName()
+	compatPackageName := common.CompatPackageName()
 
 	if aScanner.packageName == "" {
 		if package_file, ok = x.(*ast.File); ok {
@@ -257,12 +311,16 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node) bool {
 		if import_spec.Name != nil {
 			alias = import_spec.Name.Name
 		}
-		if path_name == assertPackageName {
+		if path_name == assertPackageName || path_name == compatPackageName {
 			call_qualifier := path.Base(path_name)
 			if alias != "" {
 				call_qualifier = alias
 			}
-			aScanner.imports = append(aScanner.imports, call_qualifier)
+			if path_name == assertPackageName {
+				aScanner.imports = append(aScanner.imports, call_qualifier)
+			} else {
+				aScanner.compatImports = append(aScanner.compatImports, call_qualifier)
+			}
 		}
 
 		return true // ast.inspect() can deal with this
@@ -318,7 +376,7 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node) bool {
 			relative_file_path := aScanner.module_relative_name(full_position.Filename)
 			expr_text := analyzed_expr(aScanner.imports, sel_expr.X)
 			target_func := sel_expr.Sel.Name
-			if func_hints := aScanner.assertionHintMap.HintsForName(target_func); func_hints != nil && expr_text != "" {
+			if func_hints := aScanner.assertion_hints_for_call(sel_expr.X, target_func); func_hints != nil {
 				test_name := arg_at_index(call_args, func_hints.MessageArg)
 				if test_name == common.NAME_NOT_AVAILABLE {
 					generated_msg := fmt.Sprintf("%s[%d]", relative_file_path, full_position.Line)
@@ -327,6 +385,7 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node) bool {
 				expect := AntExpect{
 					Assertion:         target_func,
 					Message:           test_name,
+					Id:                aScanner.assertion_id(test_name, relative_file_path, full_position.Line),
 					Classname:         aScanner.packageName,
 					Funcname:          aScanner.funcName,
 					Receiver:          aScanner.receiver,
@@ -344,9 +403,11 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node) bool {
 					test_name = fmt.Sprintf("Message from %s", strconv.Quote(generated_msg))
 				}
 				// The registration for the Guidance function itself
+				test_id := aScanner.assertion_id(test_name, relative_file_path, full_position.Line)
 				guidance_expect := AntGuidance{
 					Assertion:        target_func,
 					Message:          test_name,
+					Id:               test_id,
 					Classname:        aScanner.packageName,
 					Funcname:         aScanner.funcName,
 					Receiver:         aScanner.receiver,
@@ -357,9 +418,15 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node) bool {
 				aScanner.guidance = append(aScanner.guidance, &guidance_expect)
 
 				// The Related Assertion derived from target_func("AlwaysGreaterThan") => derived_target_func("Always")
+				// Guidance such as Explore or Maximize has no related assertion
+				derived_target_func := target_func_from_guidance(target_func)
+				if derived_target_func == "" {
+					return true
+				}
 				expect := AntExpect{
-					Assertion: target_func_from_guidance(target_func),
+					Assertion: derived_target_func,
 					Message:   test_name,
+					Id:        test_id,
 					Classname: aScanner.packageName,
 					Funcname:  aScanner.funcName,
 					Receiver:  aScanner.receiver,
@@ -380,6 +447,37 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node) bool {
 	return true
 }
 
+// assertion_hints_for_call finds the hints for a call to target_func qualified
+// by an import of the assert or assert/compat package, or nil for other calls
+func (aScanner *AssertionScanner) assertion_hints_for_call(qualifier ast.Expr, target_func string) *AssertionFuncInfo {
+	if analyzed_expr(aScanner.imports, qualifier) != "" {
+		return aScanner.assertionHintMap.HintsForName(target_func)
+	}
+	if analyzed_expr(aScanner.compatImports, qualifier) != "" {
+		return aScanner.compatHintMap.HintsForName(target_func)
+	}
+	return nil
+}
+
+// assertion_id must match the id used for the same assertion at runtime,
+// see makeKey() in the assert package
+func (aScanner *AssertionScanner) assertion_id(message string, file_path string, line int) string {
+	if !aScanner.locationIds {
+		return message
+	}
+	return locationkey.Key(message, aScanner.import_path(file_path), file_path, line)
+}
+
+// import_path is the import path of the package of a file named
+// relative to the module, which is also how the runtime names it
+func (aScanner *AssertionScanner) import_path(file_path string) string {
+	dir_name := path.Dir(filepath.ToSlash(file_path))
+	if dir_name == "." {
+		return aScanner.modulePath
+	}
+	return path.Join(aScanner.modulePath, dir_name)
+}
+
 func target_func_from_guidance(guidance_func string) string {
 	target_func := ""
 	if strings.HasPrefix(guidance_func, "Always") {
@@ -0,0 +1,66 @@
+package assertions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal/locationkey"
+)
+
+const serverSource = `package server
+
+import "github.com/antithesishq/antithesis-sdk-go/assert"
+
+func Serve(ok bool) {
+	assert.Always(ok, "served", nil)
+	assert.AlwaysLessThan(1, 2, "served", nil)
+}
+`
+
+func scanServer(t *testing.T, locationIds bool, package_dirs ...string) *AssertionScanner {
+	source_dir := t.TempDir()
+	aScanner := NewAssertionScanner(false, "example.com/server", "", source_dir, source_dir, locationIds, "example.com/server")
+	for _, package_dir := range package_dirs {
+		file_path := filepath.Join(source_dir, package_dir, "server.go")
+		if err := os.MkdirAll(filepath.Dir(file_path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file_path, []byte(serverSource), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		aScanner.ScanFile(file_path)
+	}
+	return aScanner
+}
+
+func TestLocationIds(t *testing.T) {
+	aScanner := scanServer(t, true, "server")
+	if len(aScanner.expects) != 2 || len(aScanner.guidance) != 1 {
+		t.Fatalf("expected two assertions and a guidance, got %d and %d", len(aScanner.expects), len(aScanner.guidance))
+	}
+
+	// At runtime, the assert package identifies assertions by the import
+	// path of their package, rather than by the directory they are
+	// cataloged from
+	for line, expect := range aScanner.expects {
+		if expect.Line != line+6 || expect.Id != locationkey.Key("served", "example.com/server/server", "/build/server/server.go", expect.Line) {
+			t.Fatalf("catalog id %q does not match the runtime id for line %d", expect.Id, expect.Line)
+		}
+	}
+	if guidance := aScanner.guidance[0]; guidance.Id != aScanner.expects[1].Id {
+		t.Fatalf("expected the guidance to share the id of its assertion, got %q", guidance.Id)
+	}
+
+	aScanner = scanServer(t, true, ".", "internal/server")
+	if len(aScanner.expects) != 4 || aScanner.expects[0].Id != "served [example.com/server/server.go:6]" || aScanner.expects[0].Id == aScanner.expects[2].Id {
+		t.Fatalf("expected distinct ids for files of the same name in different packages, got %+v", aScanner.expects)
+	}
+
+	aScanner = scanServer(t, false, "server")
+	for _, expect := range aScanner.expects {
+		if expect.Id != "served" {
+			t.Fatalf("expected assertions to be identified by their message, got %q", expect.Id)
+		}
+	}
+}
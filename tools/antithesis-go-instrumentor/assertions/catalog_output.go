@@ -22,9 +22,13 @@ type GenInfo struct {
 	ExpectedVals        []*AntExpect
 	NumericGuidanceVals []*AntGuidance
 	BooleanGuidanceVals []*AntGuidance
+	ExploreGuidanceVals []*AntGuidance
+	ParetoGuidanceVals  []*AntGuidance
 	HasAssertions       bool
 	HasNumericGuidance  bool
 	HasBooleanGuidance  bool
+	HasExploreGuidance  bool
+	HasParetoGuidance   bool
 }
 
 func IsGeneratedFile(file_name string) bool {
@@ -84,13 +88,54 @@ func expectOutputFile(dest_path string, logWriter *common.LogWriter) (*os.File,
 }
 
 func assertionNameRepr(s string) string {
-	if s == "Reachable" || s == "Unreachable" {
+	switch strings.TrimSuffix(s, "Lazy") {
+	case "Reachable", "Unreachable":
 		return fmt.Sprintf("%s(message, details)", s)
+	case "AlwaysNoError", "SometimesError":
+		return fmt.Sprintf("%s(err, message, details)", s)
+	case "SometimesErrorIs":
+		return fmt.Sprintf("%s(err, target, message, details)", s)
+	case "SometimesEach":
+		return fmt.Sprintf("%s(message, bucket, details)", s)
+	case "Equal", "NotEqual":
+		return fmt.Sprintf("%s(expected, actual, message, details)", s)
+	case "Nil", "NotNil":
+		return fmt.Sprintf("%s(object, message, details)", s)
+	case "True", "False":
+		return fmt.Sprintf("%s(value, message, details)", s)
+	case "Contains":
+		return fmt.Sprintf("%s(s, element, message, details)", s)
+	case "Len":
+		return fmt.Sprintf("%s(object, length, message, details)", s)
+	case "ElementsMatch":
+		return fmt.Sprintf("%s(listA, listB, message, details)", s)
+	case "NoError", "Error":
+		return fmt.Sprintf("%s(err, message, details)", s)
+	case "ErrorIs":
+		return fmt.Sprintf("%s(err, target, message, details)", s)
+	case "Eventually":
+		return fmt.Sprintf("%s(condition, waitFor, tick, message, details)", s)
+	case "HappensBefore", "NeverAfter":
+		return fmt.Sprintf("%s(first, second, message)", s)
+	case "RegisterInvariant":
+		return fmt.Sprintf("%s(message, check)", s)
 	}
 	return fmt.Sprintf("%s(cond, message, details)", s)
 }
 
 func numericGuidanceNameRepr(s string) string {
+	switch s {
+	case "AlwaysApproxEqual":
+		return fmt.Sprintf("%s(left, right, tolerance, message, details)", s)
+	case "AlwaysInRange", "SometimesInRange":
+		return fmt.Sprintf("%s(value, low, high, message, details)", s)
+	case "SometimesWithin":
+		return fmt.Sprintf("%s(left, right, maxSkew, message, details)", s)
+	case "Maximize", "Minimize":
+		return fmt.Sprintf("%s(value, message)", s)
+	case "SometimesAtLeast", "AlwaysAtMost":
+		return fmt.Sprintf("%s(n, condition, message, details)", s)
+	}
 	return fmt.Sprintf("%s(left, right, message, details)", s)
 }
 
@@ -98,6 +143,14 @@ func booleanGuidanceNameRepr(s string) string {
 	return fmt.Sprintf("%s(pairs, message, details)", s)
 }
 
+func exploreGuidanceNameRepr(s string) string {
+	return fmt.Sprintf("%s(message, state)", s)
+}
+
+func paretoGuidanceNameRepr(s string) string {
+	return fmt.Sprintf("%s(message, dimensions)", s)
+}
+
 func hitRepr(b bool) string {
 	if !b {
 		return "notHit"
@@ -132,6 +185,8 @@ func guidanceFnRepr(n GuidanceFnType) string {
 		gp = "minimize"
 	case GuidanceFnExplore:
 		gp = "explore"
+	case GuidanceFnPareto:
+		gp = "pareto"
 	case GuidanceFnWantAll:
 		gp = "all"
 	case GuidanceFnWantNone:
@@ -174,10 +229,12 @@ func GenerateAssertionsCatalog(moduleName string, genInfo *GenInfo) {
 		"textRepr":                textRepr,
 		"numericGuidanceNameRepr": numericGuidanceNameRepr,
 		"booleanGuidanceNameRepr": booleanGuidanceNameRepr,
+		"exploreGuidanceNameRepr": exploreGuidanceNameRepr,
+		"paretoGuidanceNameRepr":  paretoGuidanceNameRepr,
 		"guidanceFnRepr":          guidanceFnRepr,
 	})
 
-	all_template_text := getExpectorText() + getNumericGuidanceText() + getBooleanGuidanceText()
+	all_template_text := getExpectorText() + getNumericGuidanceText() + getBooleanGuidanceText() + getExploreGuidanceText() + getParetoGuidanceText()
 	if tmpl, err = tmpl.Parse(all_template_text); err != nil {
 		panic(err)
 	}
@@ -203,7 +260,7 @@ func getExpectorText() string {
 // Generated on {{.CreateDate}} 
 // ----------------------------------------------------
 
-{{if .HasAssertions -}}import "{{.AssertPackageName}}"{{- end}}
+{{if or .HasAssertions .HasNumericGuidance .HasBooleanGuidance .HasExploreGuidance .HasParetoGuidance -}}import "{{.AssertPackageName}}"{{- end}}
 
 {{if .HasAssertions -}}
 func init() {
@@ -230,10 +287,11 @@ func init() {
 	{{- $classname := textRepr .Classname -}}
 	{{- $funcname := textRepr .Funcname -}}
 	{{- $filename := textRepr .Filename -}}
-	{{- $displayname := textRepr .Assertion}}
+	{{- $displayname := textRepr .DisplayName -}}
+	{{- $id := textRepr .Id}}
 
   // {{$assertionName}}
-  assert.AssertRaw({{$cond}}, {{$message}}, noDetails, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, {{$didHit}}, {{$mustHit}}, {{$assertType}}, {{$displayname}}, {{$message}})
+  assert.AssertRaw({{$cond}}, {{$message}}, noDetails, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, {{$didHit}}, {{$mustHit}}, {{$assertType}}, {{$displayname}}, {{$id}})
 	{{- end}}
 }
 {{- end}}
@@ -258,10 +316,11 @@ func init() {
 	{{- $classname := textRepr .Classname -}}
 	{{- $funcname := textRepr .Funcname -}}
 	{{- $filename := textRepr .Filename -}}
+	{{- $id := textRepr .Id -}}
   {{- $guidanceFn := guidanceFnRepr .GuidanceFuncInfo.GuidanceFn}}
 
   // {{$guidanceName}}
-  assert.NumericGuidanceRaw(left, right, {{$message}}, {{$message}}, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, {{$guidanceFn}}, notHit)
+  assert.NumericGuidanceRaw(left, right, {{$message}}, {{$id}}, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, {{$guidanceFn}}, notHit)
   {{- end}}
 }
 {{- end}}
@@ -285,10 +344,63 @@ func init() {
 	{{- $classname := textRepr .Classname -}}
 	{{- $funcname := textRepr .Funcname -}}
 	{{- $filename := textRepr .Filename -}}
+	{{- $id := textRepr .Id -}}
   {{- $guidanceFn := guidanceFnRepr .GuidanceFuncInfo.GuidanceFn}}
 
   // {{$guidanceName}}
-  assert.BooleanGuidanceRaw(named_bools, {{$message}}, {{$message}}, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, {{$guidanceFn}}, notHit)
+  assert.BooleanGuidanceRaw(named_bools, {{$message}}, {{$id}}, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, {{$guidanceFn}}, notHit)
+  {{- end}}
+}
+{{- end}}
+`
+
+	return text
+}
+
+func getExploreGuidanceText() string {
+	const text = `
+
+{{if .HasExploreGuidance -}}
+func init() {
+
+  const notHit = false
+
+  {{- range .ExploreGuidanceVals }}
+  {{- $guidanceName := exploreGuidanceNameRepr .Assertion -}}
+	{{- $message := textRepr .Message -}}
+	{{- $classname := textRepr .Classname -}}
+	{{- $funcname := textRepr .Funcname -}}
+	{{- $filename := textRepr .Filename -}}
+	{{- $id := textRepr .Id}}
+
+  // {{$guidanceName}}
+  assert.JSONGuidanceRaw(nil, {{$message}}, {{$id}}, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, notHit)
+  {{- end}}
+}
+{{- end}}
+`
+
+	return text
+}
+
+func getParetoGuidanceText() string {
+	const text = `
+
+{{if .HasParetoGuidance -}}
+func init() {
+
+  const notHit = false
+
+  {{- range .ParetoGuidanceVals }}
+  {{- $guidanceName := paretoGuidanceNameRepr .Assertion -}}
+	{{- $message := textRepr .Message -}}
+	{{- $classname := textRepr .Classname -}}
+	{{- $funcname := textRepr .Funcname -}}
+	{{- $filename := textRepr .Filename -}}
+	{{- $id := textRepr .Id}}
+
+  // {{$guidanceName}}
+  assert.ParetoGuidanceRaw(nil, {{$message}}, {{$id}}, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, notHit)
   {{- end}}
 }
 {{- end}}
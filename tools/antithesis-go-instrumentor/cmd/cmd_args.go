@@ -26,6 +26,7 @@ type CommandArgs struct {
 	VersionText         string
 	ShowVersion         bool
 	InvalidArgs         bool
+	LocationIds         bool
 	wantsInstrumentor   bool
 }
 
@@ -39,6 +40,7 @@ func ParseArgs(versionText string) *CommandArgs {
 	catalogDirPtr := flag.String("catalog_dir", "", "file path where assertion catalog will be generated")
 	instrVersionPtr := flag.String("instrumentor_version", "latest", "version of the SDK instrumentation package to require")
 	localSDKPathPtr := flag.String("local_sdk_path", "", "path to the local Antithesis SDK")
+	locationIdsPtr := flag.Bool("location_ids", false, "identify assertions by message, file and line, for programs built with the 'antithesis_location_ids' tag (default to false)")
 	flag.Parse()
 
 	cmdArgs := CommandArgs{
@@ -58,6 +60,7 @@ func ParseArgs(versionText string) *CommandArgs {
 	cmdArgs.instrumentorVersion = strings.TrimSpace(*instrVersionPtr)
 	cmdArgs.localSDKPath = strings.TrimSpace(*localSDKPathPtr)
 	cmdArgs.VersionText = versionText
+	cmdArgs.LocationIds = *locationIdsPtr
 
 	// Verify we have the expected number of positional arguments
 	numArgsRequired := 1
@@ -121,6 +124,9 @@ func (ca *CommandArgs) ShowArguments() {
 	if ca.catalogDir != "" {
 		ca.logWriter.Printf("catalogDir: %q", ca.catalogDir)
 	}
+	if ca.LocationIds {
+		ca.logWriter.Printf("locationIds: %v", ca.LocationIds)
+	}
 	if ca.wantsInstrumentor {
 		ca.logWriter.Printf("outputDir: %q", ca.outputDir)
 		if ca.excludeFile != "" {
@@ -190,6 +196,7 @@ func (ca *CommandArgs) NewCommandFiles() (cfx *CommandFiles, err error) {
 	cfx = &CommandFiles{
 		outputDirectory:     outputDirectory,
 		inputDirectory:      customerInputDirectory,
+		modulePath:          moduleName,
 		customerDirectory:   customerDirectory,
 		notifierDirectory:   notifierDirectory,
 		symbolsDirectory:    symbolsDirectory,
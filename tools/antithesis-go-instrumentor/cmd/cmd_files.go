@@ -61,6 +61,10 @@ type CommandFiles struct {
 	// Contains a go.mod file
 	inputDirectory string
 
+	// The module path declared in the go.mod file
+	// of the inputDirectory
+	modulePath string
+
 	// Option file containing a list (one per line) of
 	// any files or directories to be excluded from both
 	// instumentation and assertion scanning.  Empty lines
@@ -162,6 +166,10 @@ func (cfx *CommandFiles) GetSourceDir() string {
 	return cfx.inputDirectory
 }
 
+func (cfx *CommandFiles) GetModulePath() string {
+	return cfx.modulePath
+}
+
 // Full instrumentation targets the customerDirectory
 // Assertions only mode will target in-place (same as inputDirectory)
 func (cfx *CommandFiles) GetTargetDir() string {
@@ -6,6 +6,7 @@ const (
 	NAME_NOT_AVAILABLE         = "anonymous"
 	ANTITHESIS_SDK_MODULE      = "github.com/antithesishq/antithesis-sdk-go"
 	ASSERT_PACKAGE             = "assert"
+	COMPAT_PACKAGE             = "assert/compat"
 	INSTRUMENTATION_PACKAGE    = "instrumentation"
 	NOTIFIER_MODULE_NAME       = "antithesis.notifier"
 	GENERATED_SUFFIX           = "_antithesis_catalog.go"
@@ -26,6 +27,10 @@ func AssertPackageName() string {
 	return SDKPackageName(ASSERT_PACKAGE)
 }
 
+func CompatPackageName() string {
+	return SDKPackageName(COMPAT_PACKAGE)
+}
+
 func InstrumentationPackageName() string {
 	return SDKPackageName(INSTRUMENTATION_PACKAGE)
 }